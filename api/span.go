@@ -0,0 +1,82 @@
+package api
+
+import (
+	"sync"
+
+	"github.com/0xPolygon/panoptichain/network"
+)
+
+// maxSpans is the number of spans kept per network. Heimdall commits the next
+// span before the current one ends, so a few spans are needed to find the one
+// containing a given Bor block.
+const maxSpans = 8
+
+// Span is a Bor span and the signer addresses of its selected producers.
+type Span struct {
+	ID         uint64
+	StartBlock uint64
+	EndBlock   uint64
+	Producers  []string
+}
+
+// HasProducer returns whether the signer is one of the span's selected
+// producers. The signer is expected to be a lowercase hex address.
+func (s Span) HasProducer(signer string) bool {
+	for _, producer := range s.Producers {
+		if producer == signer {
+			return true
+		}
+	}
+
+	return false
+}
+
+type spanStore struct {
+	mu    sync.RWMutex
+	spans []Span
+}
+
+// spans maps the network name to a spanStore.
+var spans sync.Map
+
+// SetSpan stores the span for the network. Heimdall providers call this so the
+// span can be cross-referenced against Bor blocks.
+func SetSpan(n network.Network, span Span) {
+	value, _ := spans.LoadOrStore(n.GetName(), &spanStore{})
+	store := value.(*spanStore)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for i, s := range store.spans {
+		if s.ID == span.ID {
+			store.spans[i] = span
+			return
+		}
+	}
+
+	store.spans = append(store.spans, span)
+	if len(store.spans) > maxSpans {
+		store.spans = store.spans[1:]
+	}
+}
+
+// GetSpan returns the span containing the Bor block number.
+func GetSpan(n network.Network, block uint64) (Span, bool) {
+	value, ok := spans.Load(n.GetName())
+	if !ok {
+		return Span{}, false
+	}
+	store := value.(*spanStore)
+
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	for _, s := range store.spans {
+		if block >= s.StartBlock && block <= s.EndBlock {
+			return s, true
+		}
+	}
+
+	return Span{}, false
+}
@@ -14,7 +14,8 @@ import (
 	"github.com/0xPolygon/panoptichain/network"
 )
 
-// refreshInterval is how long validators will be cached for.
+// refreshInterval is how long validators will be cached for if the network's
+// validator_cache_ttl isn't configured.
 const refreshInterval = time.Hour
 
 type Validator interface {
@@ -106,24 +107,51 @@ type ValidatorsCache struct {
 // cache maps network.Network to ValidatorsCache.
 var cache sync.Map
 
-// Validators queries the Heimdall API for the validator set. The validator set
-// is cached based on the refreshInterval.
-func Validators(n network.Network) ([]Validator, error) {
-	var path *string
-	var version uint = 1
+// HeimdallEndpoint is the Heimdall REST URL and API version used to query a
+// network's validators.
+type HeimdallEndpoint struct {
+	URL     string
+	Version uint
+}
+
+// endpointKey identifies the Heimdall provider of a network, since there can be
+// more than one per network.
+type endpointKey struct {
+	network string
+	label   string
+}
+
+// endpoints maps the endpointKey to the provider's active HeimdallEndpoint.
+var endpoints sync.Map
 
-	for _, heimdall := range config.Config().Providers.HeimdallEndpoints {
-		if heimdall.Name == n.GetName() {
-			path = &heimdall.HeimdallURL
-			version = heimdall.Version
-			break
+// SetHeimdallEndpoint sets the endpoint used to query the network's validators.
+// Heimdall providers call this when they detect the API version or fail over to
+// another endpoint, otherwise the endpoint in the config is used.
+func SetHeimdallEndpoint(n network.Network, label string, endpoint HeimdallEndpoint) {
+	endpoints.Store(endpointKey{network: n.GetName(), label: label}, endpoint)
+}
+
+// getHeimdallEndpoint returns the active endpoint of the network's Heimdall
+// provider with the first label, so the same provider is used consistently.
+func getHeimdallEndpoint(n network.Network) (HeimdallEndpoint, bool) {
+	var endpoint HeimdallEndpoint
+	var label *string
+
+	endpoints.Range(func(key, value any) bool {
+		k := key.(endpointKey)
+		if k.network == n.GetName() && (label == nil || k.label < *label) {
+			label = &k.label
+			endpoint = value.(HeimdallEndpoint)
 		}
-	}
+		return true
+	})
 
-	if path == nil {
-		return nil, errors.New("no validators for this network")
-	}
+	return endpoint, label != nil
+}
 
+// Validators queries the Heimdall API for the validator set. The validator set
+// is cached based on the network's validator cache TTL.
+func Validators(n network.Network) ([]Validator, error) {
 	value, ok := cache.Load(n)
 	if ok {
 		vc, ok := value.(ValidatorsCache)
@@ -132,10 +160,46 @@ func Validators(n network.Network) ([]Validator, error) {
 		}
 
 		if time.Now().Before(vc.ttl) {
+			getCacheStats(n).record(func(s *ValidatorCacheStats) { s.Hits++ })
 			return vc.validators, nil
 		}
 	}
 
+	getCacheStats(n).record(func(s *ValidatorCacheStats) { s.Misses++ })
+	return fetchValidators(n, RefreshExpired)
+}
+
+// FetchValidators queries the Heimdall API for the validator set, bypassing and
+// then refreshing the cache.
+func FetchValidators(n network.Network) ([]Validator, error) {
+	return fetchValidators(n, RefreshPolled)
+}
+
+// fetchValidators queries the Heimdall API for the validator set and stores it
+// in the cache. The reason is recorded in the cache stats.
+func fetchValidators(n network.Network, reason string) ([]Validator, error) {
+	var path *string
+	var version uint = 1
+
+	if endpoint, ok := getHeimdallEndpoint(n); ok {
+		path = &endpoint.URL
+		version = endpoint.Version
+	} else {
+		for _, heimdall := range config.Config().Providers.HeimdallEndpoints {
+			if heimdall.Name == n.GetName() && len(heimdall.HeimdallURL) > 0 {
+				path = &heimdall.HeimdallURL
+				if heimdall.Version > 0 {
+					version = heimdall.Version
+				}
+				break
+			}
+		}
+	}
+
+	if path == nil {
+		return nil, errors.New("no validators for this network")
+	}
+
 	var validators []Validator
 	var err error
 	switch version {
@@ -151,8 +215,9 @@ func Validators(n network.Network) ([]Validator, error) {
 
 	cache.Store(n, ValidatorsCache{
 		validators: validators,
-		ttl:        time.Now().Add(refreshInterval),
+		ttl:        time.Now().Add(cacheTTL(n)),
 	})
+	getCacheStats(n).record(func(s *ValidatorCacheStats) { s.Refreshes[reason]++ })
 
 	return validators, nil
 }
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/panoptichain/config"
+	"github.com/0xPolygon/panoptichain/network"
+)
+
+// forcedRefreshInterval is the minimum time between forced validator cache
+// refreshes of a network.
+const forcedRefreshInterval = 30 * time.Second
+
+// The reasons the validator cache is refreshed.
+const (
+	RefreshExpired = "expired"
+	RefreshForced  = "forced"
+	RefreshPolled  = "polled"
+)
+
+// ValidatorCacheStats counts the validator cache lookups and refreshes of a
+// network.
+type ValidatorCacheStats struct {
+	Hits   uint64
+	Misses uint64
+
+	// Refreshes maps the refresh reason to the number of refreshes.
+	Refreshes map[string]uint64
+
+	// RateLimited is the number of forced refreshes skipped because the cache
+	// was force refreshed within the forcedRefreshInterval.
+	RateLimited uint64
+}
+
+type cacheStats struct {
+	mu         sync.Mutex
+	stats      ValidatorCacheStats
+	lastForced time.Time
+}
+
+func (c *cacheStats) record(f func(*ValidatorCacheStats)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f(&c.stats)
+}
+
+// allowForcedRefresh returns whether a forced refresh can happen now, and if
+// so, marks that it happened.
+func (c *cacheStats) allowForcedRefresh() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if time.Since(c.lastForced) < forcedRefreshInterval {
+		c.stats.RateLimited++
+		return false
+	}
+
+	c.lastForced = time.Now()
+	return true
+}
+
+// stats maps the network name to cacheStats.
+var stats sync.Map
+
+func getCacheStats(n network.Network) *cacheStats {
+	value, _ := stats.LoadOrStore(n.GetName(), &cacheStats{
+		stats: ValidatorCacheStats{Refreshes: make(map[string]uint64)},
+	})
+
+	return value.(*cacheStats)
+}
+
+// GetValidatorCacheStats returns the cumulative validator cache stats of the
+// network. The stats aren't reset, so every provider of the network can take
+// the difference from the stats it previously got.
+func GetValidatorCacheStats(n network.Network) ValidatorCacheStats {
+	c := getCacheStats(n)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.stats
+	s.Refreshes = make(map[string]uint64)
+	for reason, count := range c.stats.Refreshes {
+		s.Refreshes[reason] = count
+	}
+
+	return s
+}
+
+// Sub returns the stats counted since prev.
+func (s ValidatorCacheStats) Sub(prev ValidatorCacheStats) ValidatorCacheStats {
+	diff := ValidatorCacheStats{
+		Hits:        s.Hits - prev.Hits,
+		Misses:      s.Misses - prev.Misses,
+		Refreshes:   make(map[string]uint64),
+		RateLimited: s.RateLimited - prev.RateLimited,
+	}
+
+	for reason, count := range s.Refreshes {
+		diff.Refreshes[reason] = count - prev.Refreshes[reason]
+	}
+
+	return diff
+}
+
+// cacheTTL returns how long the network's validators are cached for.
+func cacheTTL(n network.Network) time.Duration {
+	for _, heimdall := range config.Config().Providers.HeimdallEndpoints {
+		if heimdall.Name == n.GetName() && heimdall.ValidatorCacheTTL > 0 {
+			return time.Duration(heimdall.ValidatorCacheTTL) * time.Second
+		}
+	}
+
+	return refreshInterval
+}
+
+// Signer returns the validator with the given signer address. If the signer
+// isn't in the cached validator set, the cache is refreshed before giving up
+// because the validator set may have changed since it was cached, such as
+// after a signer rotation. These forced refreshes are rate limited.
+func Signer(n network.Network, signer string) (Validator, bool, error) {
+	signers, err := Signers(n)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if v, ok := signers[signer]; ok {
+		return v, true, nil
+	}
+
+	if !getCacheStats(n).allowForcedRefresh() {
+		return nil, false, nil
+	}
+
+	validators, err := fetchValidators(n, RefreshForced)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, v := range validators {
+		if v.GetSigner() == signer {
+			return v, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
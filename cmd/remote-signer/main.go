@@ -0,0 +1,75 @@
+// Command remote-signer serves the remotesigner stand-in for a Clef compatible
+// external signer. It signs every transaction it receives without confirmation,
+// so it must only be used for local testing of the time to mine external signer.
+package main
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/0xPolygon/panoptichain/remotesigner"
+)
+
+// loadKey loads the private key from either a hex string or an encrypted
+// keystore file.
+func loadKey(hexKey, keystorePath, passwordFile string) (*ecdsa.PrivateKey, error) {
+	if hexKey != "" {
+		return crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+	}
+
+	if keystorePath == "" {
+		return nil, errors.New("either --key or --keystore is required")
+	}
+
+	data, err := os.ReadFile(keystorePath)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := os.ReadFile(passwordFile)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keystore.DecryptKey(data, strings.TrimRight(string(password), "\r\n"))
+	if err != nil {
+		return nil, err
+	}
+
+	return key.PrivateKey, nil
+}
+
+func main() {
+	addr := flag.String("addr", "localhost:8550", "Address to listen on")
+	hexKey := flag.String("key", os.Getenv("REMOTE_SIGNER_KEY"), "Hex encoded private key")
+	keystorePath := flag.String("keystore", "", "Path to an encrypted keystore file")
+	passwordFile := flag.String("password-file", "", "Path to the keystore password file")
+
+	flag.Parse()
+
+	key, err := loadKey(*hexKey, *keystorePath, *passwordFile)
+	if err != nil {
+		slog.Error("Failed to load key", "error", err)
+		os.Exit(1)
+	}
+
+	server, err := remotesigner.NewServer(key)
+	if err != nil {
+		slog.Error("Failed to create remote signer", "error", err)
+		os.Exit(1)
+	}
+
+	slog.Info("Starting remote signer", "addr", *addr, "account", crypto.PubkeyToAddress(key.PublicKey))
+	if err := http.ListenAndServe(*addr, server); err != nil {
+		slog.Error("Failed to serve", "error", err)
+		os.Exit(1)
+	}
+}
@@ -4,6 +4,7 @@ package config
 
 import (
 	"flag"
+	"fmt"
 	"os"
 	"reflect"
 	"strings"
@@ -20,12 +21,18 @@ type Runner struct {
 // Providers encloses the different providers configurations. Providers are
 // responsible for fetching data.
 type Providers struct {
-	RPCs              []RPC              `mapstructure:"rpc" validate:"dive"`
-	HeimdallEndpoints []HeimdallEndpoint `mapstructure:"heimdall" validate:"dive"`
-	SensorNetworks    []SensorNetwork    `mapstructure:"sensor_network" validate:"dive"`
-	HashDivergence    *HashDivergence    `mapstructure:"hash_divergence"`
-	System            *System            `mapstructure:"system"`
-	ExchangeRates     *ExchangeRates     `mapstructure:"exchange_rates"`
+	RPCs                 []RPC                 `mapstructure:"rpc" validate:"dive"`
+	HeimdallEndpoints    []HeimdallEndpoint    `mapstructure:"heimdall" validate:"dive"`
+	SensorNetworks       []SensorNetwork       `mapstructure:"sensor_network" validate:"dive"`
+	HashDivergence       *HashDivergence       `mapstructure:"hash_divergence"`
+	System               *System               `mapstructure:"system"`
+	ExchangeRates        *ExchangeRates        `mapstructure:"exchange_rates"`
+	ValidatorScorecard   *ValidatorScorecard   `mapstructure:"validator_scorecard"`
+	ValidatorSet         *ValidatorSet         `mapstructure:"validator_set"`
+	StateSync            *StateSync            `mapstructure:"state_sync"`
+	BridgeReconciliation *BridgeReconciliation `mapstructure:"bridge_reconciliation"`
+	ExitRootConsistency  *ExitRootConsistency  `mapstructure:"exit_root_consistency"`
+	BatchPipeline        *BatchPipeline        `mapstructure:"batch_pipeline"`
 }
 
 // RPC defines the various RPC providers that will be monitored.
@@ -38,6 +45,35 @@ type RPC struct {
 	TimeToMine    *TimeToMine       `mapstructure:"time_to_mine"`
 	Accounts      []string          `mapstructure:"accounts"`
 	BlockLookBack *uint64           `mapstructure:"block_look_back"`
+	Calls         []ContractCall    `mapstructure:"calls" validate:"dive"`
+	TokenBalances []TokenBalance    `mapstructure:"token_balances" validate:"dive"`
+
+	// BridgeTokens are the tokens whose balance locked in the zkEVM bridge is
+	// tracked. The zero address tracks the bridge's ether balance.
+	BridgeTokens []Token `mapstructure:"bridge_tokens" validate:"dive"`
+
+	// Rollups configures the rollups of the rollup manager contract.
+	Rollups []Rollup `mapstructure:"rollups" validate:"dive"`
+
+	// CheckpointNetwork is the name of the Polygon PoS network whose
+	// checkpoints are submitted to the checkpoint contract and whose state
+	// syncs are sent by the state sender contract.
+	CheckpointNetwork string `mapstructure:"checkpoint_network"`
+
+	// OPStack configures the OP Stack profile.
+	OPStack *OPStack `mapstructure:"op_stack"`
+}
+
+// OPStack configures the rollup node and L1 contracts of an OP Stack chain.
+// The output proposals are read from the dispute game factory if it's set,
+// otherwise from the L2 output oracle.
+type OPStack struct {
+	RollupNodeURL             string  `mapstructure:"rollup_node_url" validate:"omitempty,url"`
+	L1URL                     string  `mapstructure:"l1_url" validate:"omitempty,url"`
+	L2OutputOracleAddress     *string `mapstructure:"l2_output_oracle_address"`
+	DisputeGameFactoryAddress *string `mapstructure:"dispute_game_factory_address"`
+	BatcherAddress            *string `mapstructure:"batcher_address"`
+	ProposerAddress           *string `mapstructure:"proposer_address"`
 }
 
 // ContractAddresses maps specific contracts to their addresses. This is used to
@@ -52,19 +88,97 @@ type ContractAddresses struct {
 	GlobalExitRootL2Address *string `mapstructure:"global_exit_root_l2_address"`
 	ZkEVMBridgeAddress      *string `mapstructure:"zkevm_bridge_address"`
 	RollupManagerAddress    *string `mapstructure:"rollup_manager_address"`
+
+	// Multicall3
+	MulticallAddress *string `mapstructure:"multicall_address"`
+}
+
+// ContractCall configures a read-only contract call whose return value is
+// exported as a gauge. The signature is a human-readable function signature
+// such as "balanceOf(address)(uint256)".
+type ContractCall struct {
+	Label     string   `mapstructure:"label" validate:"required"`
+	Address   string   `mapstructure:"address" validate:"required"`
+	Signature string   `mapstructure:"signature" validate:"required"`
+	Args      []string `mapstructure:"args"`
+	Output    uint     `mapstructure:"output"`
+	Decimals  uint8    `mapstructure:"decimals"`
+	Scale     float64  `mapstructure:"scale"`
+}
+
+// TokenBalance configures the ERC20 token balances that will be tracked for an
+// account.
+type TokenBalance struct {
+	Account string  `mapstructure:"account" validate:"required"`
+	Tokens  []Token `mapstructure:"tokens" validate:"dive"`
+}
+
+// Token is an ERC20 token. The decimals are fetched from the contract, as is the
+// symbol if it is not set. The symbol is also used to look up exchange rates.
+type Token struct {
+	Address             string   `mapstructure:"address" validate:"required"`
+	Symbol              string   `mapstructure:"symbol"`
+	LowBalanceThreshold *float64 `mapstructure:"low_balance_threshold"`
+}
+
+// Rollup configures a rollup of the rollup manager contract, matched by its
+// rollup ID or chain ID. Rollups that aren't configured are monitored with a
+// generated network name.
+type Rollup struct {
+	RollupID *uint32 `mapstructure:"rollup_id" validate:"required_without=ChainID"`
+	ChainID  *uint64 `mapstructure:"chain_id" validate:"required_without=RollupID"`
+	Name     string  `mapstructure:"name"`
+	Label    string  `mapstructure:"label"`
+	Interval uint    `mapstructure:"interval"`
+
+	// TrustedSequencerURL overrides the URL from the rollup contract.
+	TrustedSequencerURL string `mapstructure:"trusted_sequencer_url" validate:"omitempty,url"`
+
+	// Disabled skips monitoring the rollup's trusted sequencer.
+	Disabled bool `mapstructure:"disabled"`
 }
 
 // TimeToMine configures the time to mine provider. This periodically sends
 // transactions on the network and records how long they took to be recorded in
 // a block.
 type TimeToMine struct {
-	Sender           string `mapstructure:"sender" validate:"required"`
-	SenderPrivateKey string `mapstructure:"sender_private_key" validate:"required"`
-	Receiver         string `mapstructure:"receiver" validate:"required"`
-	Value            int64  `mapstructure:"value" validate:"required"`
-	Data             string `mapstructure:"data"`
-	GasPriceFactor   int64  `mapstructure:"gas_price_factor"`
-	GasLimit         uint64 `mapstructure:"gas_limit" validate:"required"`
+	Sender           string  `mapstructure:"sender" validate:"required"`
+	SenderPrivateKey string  `mapstructure:"sender_private_key" validate:"required_without=Signer"`
+	Signer           *Signer `mapstructure:"signer"`
+	Receiver         string  `mapstructure:"receiver" validate:"required"`
+	Value            int64   `mapstructure:"value" validate:"required"`
+	Data             string  `mapstructure:"data"`
+	GasPriceFactor   int64   `mapstructure:"gas_price_factor"`
+	GasLimit         uint64  `mapstructure:"gas_limit" validate:"required"`
+
+	// DynamicFee sends EIP-1559 transactions instead of legacy transactions.
+	DynamicFee bool `mapstructure:"dynamic_fee"`
+
+	// TipStrategy determines the priority fee of EIP-1559 transactions. It is
+	// one of "suggested" (eth_maxPriorityFeePerGas), "fixed" (TipCap), or
+	// "fee_history" (TipPercentile of recent eth_feeHistory rewards).
+	TipStrategy   string  `mapstructure:"tip_strategy" validate:"omitempty,oneof=suggested fixed fee_history"`
+	TipCap        float64 `mapstructure:"tip_cap" validate:"required_if=TipStrategy fixed"`
+	TipPercentile float64 `mapstructure:"tip_percentile" validate:"gte=0,lte=100"`
+
+	// Timeout is the number of seconds to wait for a transaction to be
+	// included before it is considered stuck and replaced.
+	Timeout uint `mapstructure:"timeout"`
+
+	// OracleBlocks is the number of blocks after a gas price suggestion whose
+	// transactions are used to evaluate the suggestion.
+	OracleBlocks uint64 `mapstructure:"oracle_blocks"`
+}
+
+// Signer configures how time to mine transactions are signed so the sender
+// private key doesn't need to be in the config.
+type Signer struct {
+	// Type is either "keystore" for an encrypted go-ethereum keystore file, or
+	// "external" for a Clef compatible external signer.
+	Type         string `mapstructure:"type" validate:"required,oneof=keystore external"`
+	Keystore     string `mapstructure:"keystore" validate:"required_if=Type keystore"`
+	PasswordFile string `mapstructure:"password_file" validate:"required_if=Type keystore"`
+	URL          string `mapstructure:"url" validate:"required_if=Type external"`
 }
 
 // HashDivergence configures the hash divergence provider. This tracks whether
@@ -73,6 +187,57 @@ type HashDivergence struct {
 	Interval uint `mapstructure:"interval"`
 }
 
+// ValidatorScorecard configures the validator scorecard provider. This
+// aggregates the Bor and Heimdall participation of each validator over a
+// rolling window.
+type ValidatorScorecard struct {
+	Interval uint   `mapstructure:"interval"`
+	Window   uint   `mapstructure:"window"`
+	Path     string `mapstructure:"path"`
+}
+
+// ValidatorSet configures the validator set provider. This polls the validator
+// set of each network with a heimdall provider and tracks changes to it.
+type ValidatorSet struct {
+	Interval uint `mapstructure:"interval"`
+}
+
+// StateSync configures the state sync provider. This correlates state syncs
+// sent on L1 with state syncs committed on Bor.
+type StateSync struct {
+	Interval uint `mapstructure:"interval"`
+
+	// StuckAfter is the number of seconds a state sync can be pending before it
+	// is considered stuck.
+	StuckAfter uint `mapstructure:"stuck_after"`
+}
+
+// BridgeReconciliation configures the bridge reconciliation provider. This
+// matches bridge deposits with their claims on the destination network.
+type BridgeReconciliation struct {
+	Interval uint `mapstructure:"interval"`
+
+	// UnclaimedThresholds are the ages in seconds after which unclaimed
+	// deposits are counted.
+	UnclaimedThresholds []uint `mapstructure:"unclaimed_thresholds"`
+}
+
+// ExitRootConsistency configures the exit root consistency provider. This
+// checks that the L1 global exit roots are used on L2 within the SLA.
+type ExitRootConsistency struct {
+	Interval uint `mapstructure:"interval"`
+
+	// SLA is the number of seconds an L1 global exit root can take to be used
+	// on L2.
+	SLA uint `mapstructure:"sla"`
+}
+
+// BatchPipeline configures the batch pipeline provider. This tracks how long
+// the zkEVM batches take to be virtualized and verified.
+type BatchPipeline struct {
+	Interval uint `mapstructure:"interval"`
+}
+
 // System configures the system provider. This keeps system diagnostic metrics
 // such as uptime.
 type System struct {
@@ -90,11 +255,28 @@ type ExchangeRates struct {
 // HeimdallEndpoint configures the heimdall provider. This provider fetches data
 // from the consensus layer endpoints for Polygon PoS chains.
 type HeimdallEndpoint struct {
-	Name          string `mapstructure:"name"`
-	TendermintURL string `mapstructure:"tendermint_url" validate:"url,required_with=Name"`
-	HeimdallURL   string `mapstructure:"heimdall_url" validate:"url,required_with=Name"`
-	Label         string `mapstructure:"label" validate:"required_with=Name"`
-	Interval      uint   `mapstructure:"interval"`
+	Name          string         `mapstructure:"name"`
+	TendermintURL string         `mapstructure:"tendermint_url" validate:"omitempty,url"`
+	HeimdallURL   string         `mapstructure:"heimdall_url" validate:"omitempty,url"`
+	Label         string         `mapstructure:"label" validate:"required_with=Name"`
+	Interval      uint           `mapstructure:"interval"`
+	Version       uint           `mapstructure:"version" validate:"omitempty,oneof=1 2"`
+	Endpoints     []HeimdallURLs `mapstructure:"endpoints" validate:"dive"`
+
+	// StaleAfter is the number of seconds the block height can stay the same
+	// before failing over to the next endpoint.
+	StaleAfter uint `mapstructure:"stale_after"`
+
+	// ValidatorCacheTTL is the number of seconds the network's validator set is
+	// cached for.
+	ValidatorCacheTTL uint `mapstructure:"validator_cache_ttl"`
+}
+
+// HeimdallURLs is a Tendermint and Heimdall REST URL pair of the same node. If
+// the version is not set, it is detected automatically.
+type HeimdallURLs struct {
+	TendermintURL string `mapstructure:"tendermint_url" validate:"url,required"`
+	HeimdallURL   string `mapstructure:"heimdall_url" validate:"url,required"`
 	Version       uint   `mapstructure:"version" validate:"omitempty,oneof=1 2"`
 }
 
@@ -123,12 +305,20 @@ type HTTP struct {
 	Path      string `mapstructure:"path"`
 }
 
+// The chain profiles enable the features specific to a chain's stack.
+const (
+	ProfilePolygonPoS   = "polygon_pos"
+	ProfilePolygonZkEVM = "polygon_zkevm"
+	ProfileOPStack      = "op_stack"
+)
+
 // Network defines metadata about a blockchain network.
 type Network struct {
 	Name         string `mapstructure:"name" validate:"required"`
 	ChainID      uint64 `mapstructure:"chain_id"`
 	PolygonPoS   bool   `mapstructure:"polygon_pos"`
 	PolygonZkEVM bool   `mapstructure:"polygon_zkevm"`
+	Profile      string `mapstructure:"profile" validate:"omitempty,oneof=polygon_pos polygon_zkevm op_stack"`
 }
 
 // GetName returns the network name.
@@ -143,12 +333,28 @@ func (n *Network) GetChainID() uint64 {
 
 // IsPolygonPoS returns if this is a Polygon PoS chain.
 func (n *Network) IsPolygonPoS() bool {
-	return n.PolygonPoS
+	return n.GetProfile() == ProfilePolygonPoS
 }
 
 // IsPolygonZkEVM returns if the network is a Polygon zkEVM chain.
 func (n *Network) IsPolygonZkEVM() bool {
-	return n.PolygonZkEVM
+	return n.GetProfile() == ProfilePolygonZkEVM
+}
+
+// GetProfile returns the chain profile. The polygon_pos and polygon_zkevm
+// flags are used if the profile isn't set, and networks without a profile are
+// generic EVM chains.
+func (n *Network) GetProfile() string {
+	switch {
+	case len(n.Profile) > 0:
+		return n.Profile
+	case n.PolygonPoS:
+		return ProfilePolygonPoS
+	case n.PolygonZkEVM:
+		return ProfilePolygonZkEVM
+	}
+
+	return ""
 }
 
 // Logs configures logging format and verbosity options.
@@ -163,7 +369,7 @@ type config struct {
 	HTTP      HTTP      `mapstructure:"http"`
 	Providers Providers `mapstructure:"providers"`
 	Observers Observers `mapstructure:"observers"`
-	Networks  []Network `mapstructure:"networks"`
+	Networks  []Network `mapstructure:"networks" validate:"dive"`
 	Logs      Logs      `mapstructure:"logs"`
 }
 
@@ -220,5 +426,26 @@ func Init() error {
 		return err
 	}
 
+	return validateRollupLabels(c)
+}
+
+// validateRollupLabels checks that the configured rollup labels are unique.
+// The series of a stopped trusted sequencer provider are deleted by network and
+// label, so a shared label could delete the series of another rollup.
+func validateRollupLabels(cfg *config) error {
+	labels := make(map[string]struct{})
+	for _, rpc := range cfg.Providers.RPCs {
+		for _, rollup := range rpc.Rollups {
+			if len(rollup.Label) == 0 {
+				continue
+			}
+
+			if _, ok := labels[rollup.Label]; ok {
+				return fmt.Errorf("duplicate rollup label %s", rollup.Label)
+			}
+			labels[rollup.Label] = struct{}{}
+		}
+	}
+
 	return nil
 }
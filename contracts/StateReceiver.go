@@ -31,7 +31,7 @@ var (
 
 // StateReceiverMetaData contains all meta data concerning the StateReceiver contract.
 var StateReceiverMetaData = &bind.MetaData{
-	ABI: "[{\"constant\":true,\"inputs\":[],\"name\":\"SYSTEM_ADDRESS\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"lastStateId\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"syncTime\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"recordBytes\",\"type\":\"bytes\"}],\"name\":\"commitState\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"stateId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"name\":\"StateCommitted\",\"type\":\"event\"},{\"constant\":true,\"inputs\":[],\"name\":\"SYSTEM_ADDRESS\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"lastStateId\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"syncTime\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"recordBytes\",\"type\":\"bytes\"}],\"name\":\"commitState\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
 }
 
 // StateReceiverABI is the input ABI used to generate the binding from.
@@ -263,3 +263,148 @@ func (_StateReceiver *StateReceiverTransactorSession) CommitState(syncTime *big.
 	return _StateReceiver.Contract.CommitState(&_StateReceiver.TransactOpts, syncTime, recordBytes)
 }
 
+// StateReceiverStateCommittedIterator is returned from FilterStateCommitted and is used to iterate over the raw logs and unpacked data for StateCommitted events raised by the StateReceiver contract.
+type StateReceiverStateCommittedIterator struct {
+	Event *StateReceiverStateCommitted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *StateReceiverStateCommittedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(StateReceiverStateCommitted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(StateReceiverStateCommitted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *StateReceiverStateCommittedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *StateReceiverStateCommittedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// StateReceiverStateCommitted represents a StateCommitted event raised by the StateReceiver contract.
+type StateReceiverStateCommitted struct {
+	StateId *big.Int
+	Success bool
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterStateCommitted is a free log retrieval operation binding the contract event 0x5a22725590b0a51c923940223f7458512164b1113359a735e86e7f27f44791ee.
+//
+// Solidity: event StateCommitted(uint256 indexed stateId, bool success)
+func (_StateReceiver *StateReceiverFilterer) FilterStateCommitted(opts *bind.FilterOpts, stateId []*big.Int) (*StateReceiverStateCommittedIterator, error) {
+
+	var stateIdRule []interface{}
+	for _, stateIdItem := range stateId {
+		stateIdRule = append(stateIdRule, stateIdItem)
+	}
+
+	logs, sub, err := _StateReceiver.contract.FilterLogs(opts, "StateCommitted", stateIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &StateReceiverStateCommittedIterator{contract: _StateReceiver.contract, event: "StateCommitted", logs: logs, sub: sub}, nil
+}
+
+// WatchStateCommitted is a free log subscription operation binding the contract event 0x5a22725590b0a51c923940223f7458512164b1113359a735e86e7f27f44791ee.
+//
+// Solidity: event StateCommitted(uint256 indexed stateId, bool success)
+func (_StateReceiver *StateReceiverFilterer) WatchStateCommitted(opts *bind.WatchOpts, sink chan<- *StateReceiverStateCommitted, stateId []*big.Int) (event.Subscription, error) {
+
+	var stateIdRule []interface{}
+	for _, stateIdItem := range stateId {
+		stateIdRule = append(stateIdRule, stateIdItem)
+	}
+
+	logs, sub, err := _StateReceiver.contract.WatchLogs(opts, "StateCommitted", stateIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(StateReceiverStateCommitted)
+				if err := _StateReceiver.contract.UnpackLog(event, "StateCommitted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseStateCommitted is a log parse operation binding the contract event 0x5a22725590b0a51c923940223f7458512164b1113359a735e86e7f27f44791ee.
+//
+// Solidity: event StateCommitted(uint256 indexed stateId, bool success)
+func (_StateReceiver *StateReceiverFilterer) ParseStateCommitted(log types.Log) (*StateReceiverStateCommitted, error) {
+	event := new(StateReceiverStateCommitted)
+	if err := _StateReceiver.contract.UnpackLog(event, "StateCommitted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
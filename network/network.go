@@ -14,6 +14,7 @@ type Network interface {
 	GetChainID() uint64
 	IsPolygonPoS() bool
 	IsPolygonZkEVM() bool
+	GetProfile() string
 }
 
 const (
@@ -29,6 +30,9 @@ const (
 	EthereumName = "Ethereum"
 	SepoliaName  = "Sepolia"
 	GoerliName   = "Goerli"
+
+	OPMainnetName = "OP Mainnet"
+	OPSepoliaName = "OP Sepolia"
 )
 
 var PolygonMainnet = config.Network{Name: PolygonMainnetName, ChainID: 137, PolygonPoS: true}
@@ -44,6 +48,9 @@ var Ethereum = config.Network{Name: EthereumName, ChainID: 1}
 var Sepolia = config.Network{Name: SepoliaName, ChainID: 11155111}
 var Goerli = config.Network{Name: GoerliName, ChainID: 5}
 
+var OPMainnet = config.Network{Name: OPMainnetName, ChainID: 10, Profile: config.ProfileOPStack}
+var OPSepolia = config.Network{Name: OPSepoliaName, ChainID: 11155420, Profile: config.ProfileOPStack}
+
 var KnownNetworks = []Network{
 	&PolygonMainnet,
 	&PolygonMumbai,
@@ -57,6 +64,9 @@ var KnownNetworks = []Network{
 	&Ethereum,
 	&Goerli,
 	&Sepolia,
+
+	&OPMainnet,
+	&OPSepolia,
 }
 
 // GetNetworkByName converts a name like "Ethereum" into a Network object.
@@ -5,6 +5,7 @@ package observer
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"time"
 
@@ -52,6 +53,7 @@ type HeimdallBlock struct {
 				Height          string `json:"height"`
 				NumTxs          string `json:"num_txs"`
 				ProposerAddress string `json:"proposer_address"`
+				ValidatorsHash  string `json:"validators_hash"`
 			} `json:"header"`
 			Data struct {
 				Txs []string `json:"txs"`
@@ -119,6 +121,12 @@ func (b *HeimdallBlock) ProposerAddress() string {
 	return b.Result.Block.Header.ProposerAddress
 }
 
+// ValidatorsHash returns the hash of the validator set at the block's height.
+// It doesn't depend on the validators' proposer priorities.
+func (b *HeimdallBlock) ValidatorsHash() string {
+	return b.Result.Block.Header.ValidatorsHash
+}
+
 type HeimdallBlockIntervalObserver struct {
 	blockInterval *prometheus.HistogramVec
 }
@@ -420,6 +428,8 @@ func (o *HeimdallCheckpointObserver) GetCollectors() []prometheus.Collector {
 
 type HeimdallCurrentCheckpointProposerV1 HeimdallResult[api.ValidatorV1]
 
+// HeimdallCurrentCheckpointProposerV2 is the response of the Heimdall v2
+// stake/proposers/current route.
 type HeimdallCurrentCheckpointProposerV2 struct {
 	Validator api.ValidatorV2 `json:"validator"`
 }
@@ -451,6 +461,12 @@ func (o *HeimdallMissedCheckpointProposalObserver) GetCollectors() []prometheus.
 
 type ValidatorsV1 HeimdallResult[[]api.ValidatorV1]
 
+// HeimdallMilestoneProposersV2 is the response of the Heimdall v2
+// stake/proposers/{times} route.
+type HeimdallMilestoneProposersV2 struct {
+	Proposers []api.ValidatorV2 `json:"proposers"`
+}
+
 type HeimdallMissedMilestoneProposal struct {
 	missedMilestoneProposal *prometheus.CounterVec
 }
@@ -480,23 +496,34 @@ type HeimdallSpan interface {
 	GetID() uint64
 	GetStartBlock() uint64
 	GetEndBlock() uint64
+	GetSelectedProducers() []api.Validator
 }
 
 type HeimdallSpanV1 HeimdallResult[struct {
-	SpanID     uint64 `json:"span_id"`
-	StartBlock uint64 `json:"start_block"`
-	EndBlock   uint64 `json:"end_block"`
+	SpanID            uint64            `json:"span_id"`
+	StartBlock        uint64            `json:"start_block"`
+	EndBlock          uint64            `json:"end_block"`
+	SelectedProducers []api.ValidatorV1 `json:"selected_producers"`
 }]
 
 func (h HeimdallSpanV1) GetID() uint64         { return h.Result.SpanID }
 func (h HeimdallSpanV1) GetStartBlock() uint64 { return h.Result.StartBlock }
 func (h HeimdallSpanV1) GetEndBlock() uint64   { return h.Result.EndBlock }
 
+func (h HeimdallSpanV1) GetSelectedProducers() []api.Validator {
+	producers := make([]api.Validator, 0, len(h.Result.SelectedProducers))
+	for _, producer := range h.Result.SelectedProducers {
+		producers = append(producers, producer)
+	}
+	return producers
+}
+
 type HeimdallSpanV2 struct {
 	Span struct {
-		ID         uint64 `json:"id,string"`
-		StartBlock uint64 `json:"start_block,string"`
-		EndBlock   uint64 `json:"end_block,string"`
+		ID                uint64            `json:"id,string"`
+		StartBlock        uint64            `json:"start_block,string"`
+		EndBlock          uint64            `json:"end_block,string"`
+		SelectedProducers []api.ValidatorV2 `json:"selected_producers"`
 	} `json:"span"`
 }
 
@@ -504,10 +531,20 @@ func (h HeimdallSpanV2) GetID() uint64         { return h.Span.ID }
 func (h HeimdallSpanV2) GetStartBlock() uint64 { return h.Span.StartBlock }
 func (h HeimdallSpanV2) GetEndBlock() uint64   { return h.Span.EndBlock }
 
+func (h HeimdallSpanV2) GetSelectedProducers() []api.Validator {
+	producers := make([]api.Validator, 0, len(h.Span.SelectedProducers))
+	for _, producer := range h.Span.SelectedProducers {
+		producers = append(producers, producer)
+	}
+	return producers
+}
+
 type HeimdallSpanObserver struct {
-	spanID     *prometheus.GaugeVec
-	startBlock *prometheus.GaugeVec
-	endBlock   *prometheus.GaugeVec
+	spanID        *prometheus.GaugeVec
+	startBlock    *prometheus.GaugeVec
+	endBlock      *prometheus.GaugeVec
+	producers     *prometheus.GaugeVec
+	producerPower *prometheus.GaugeVec
 }
 
 func (o *HeimdallSpanObserver) Register(eb *EventBus) {
@@ -516,16 +553,259 @@ func (o *HeimdallSpanObserver) Register(eb *EventBus) {
 	o.spanID = metrics.NewGauge(metrics.Heimdall, "span_id", "The span id")
 	o.startBlock = metrics.NewGauge(metrics.Heimdall, "span_start_block", "The span start block")
 	o.endBlock = metrics.NewGauge(metrics.Heimdall, "span_end_block", "The span end block")
+	o.producers = metrics.NewGauge(metrics.Heimdall, "span_producers", "The number of selected producers in the span")
+	o.producerPower = metrics.NewGauge(
+		metrics.Heimdall,
+		"span_producer_voting_power",
+		"The voting power of each selected producer in the span",
+		"validator_id",
+		"signer_address",
+	)
 }
 
 func (o *HeimdallSpanObserver) Notify(ctx context.Context, m Message) {
 	span := m.Data().(HeimdallSpan)
+	producers := span.GetSelectedProducers()
 
 	o.spanID.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(span.GetID()))
 	o.startBlock.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(span.GetStartBlock()))
 	o.endBlock.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(span.GetEndBlock()))
+	o.producers.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(len(producers)))
+
+	// Remove producers from previous spans.
+	o.producerPower.DeletePartialMatch(prometheus.Labels{
+		"network":  m.Network().GetName(),
+		"provider": m.Provider(),
+	})
+
+	for _, producer := range producers {
+		id := fmt.Sprint(producer.GetID())
+		o.producerPower.WithLabelValues(m.Network().GetName(), m.Provider(), id, producer.GetSigner()).Set(float64(producer.GetPower()))
+	}
 }
 
 func (o *HeimdallSpanObserver) GetCollectors() []prometheus.Collector {
-	return []prometheus.Collector{o.spanID, o.startBlock, o.endBlock}
+	return []prometheus.Collector{o.spanID, o.startBlock, o.endBlock, o.producers, o.producerPower}
+}
+
+// HeimdallSpanProducerChange is the difference in selected producers between
+// two consecutive spans.
+type HeimdallSpanProducerChange struct {
+	SpanID  uint64
+	Added   []api.Validator
+	Removed []api.Validator
+}
+
+type HeimdallSpanProducerChangeObserver struct {
+	changes *prometheus.CounterVec
+	added   *prometheus.GaugeVec
+	removed *prometheus.GaugeVec
+}
+
+func (o *HeimdallSpanProducerChangeObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.SpanProducerChange, o)
+
+	o.changes = metrics.NewCounter(
+		metrics.Heimdall,
+		"span_producer_changes",
+		"The number of times a validator was added to or removed from the selected producers between spans",
+		"change",
+		"validator_id",
+		"signer_address",
+	)
+	o.added = metrics.NewGauge(metrics.Heimdall, "span_producers_added", "The number of producers added in the latest span")
+	o.removed = metrics.NewGauge(metrics.Heimdall, "span_producers_removed", "The number of producers removed in the latest span")
+}
+
+func (o *HeimdallSpanProducerChangeObserver) Notify(ctx context.Context, m Message) {
+	change := m.Data().(*HeimdallSpanProducerChange)
+
+	for _, v := range change.Added {
+		o.changes.WithLabelValues(m.Network().GetName(), m.Provider(), "added", fmt.Sprint(v.GetID()), v.GetSigner()).Inc()
+	}
+
+	for _, v := range change.Removed {
+		o.changes.WithLabelValues(m.Network().GetName(), m.Provider(), "removed", fmt.Sprint(v.GetID()), v.GetSigner()).Inc()
+	}
+
+	o.added.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(len(change.Added)))
+	o.removed.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(len(change.Removed)))
+}
+
+func (o *HeimdallSpanProducerChangeObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.changes, o.added, o.removed}
+}
+
+type HeimdallEndpoint struct {
+	Index     int
+	Version   uint
+	Failovers uint64
+}
+
+type HeimdallEndpointObserver struct {
+	index     *prometheus.GaugeVec
+	version   *prometheus.GaugeVec
+	failovers *prometheus.CounterVec
+}
+
+func (o *HeimdallEndpointObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.HeimdallEndpoint, o)
+
+	o.index = metrics.NewGauge(metrics.Heimdall, "endpoint_index", "The index of the active Heimdall endpoint")
+	o.version = metrics.NewGauge(metrics.Heimdall, "endpoint_version", "The detected or configured Heimdall version of the active endpoint")
+	o.failovers = metrics.NewCounter(metrics.Heimdall, "endpoint_failovers", "The number of times the provider failed over to another Heimdall endpoint")
+}
+
+func (o *HeimdallEndpointObserver) Notify(ctx context.Context, m Message) {
+	endpoint := m.Data().(*HeimdallEndpoint)
+
+	o.index.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(endpoint.Index))
+	o.version.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(endpoint.Version))
+	o.failovers.WithLabelValues(m.Network().GetName(), m.Provider()).Add(float64(endpoint.Failovers))
+}
+
+func (o *HeimdallEndpointObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.index, o.version, o.failovers}
+}
+
+// TendermintStatus is the response of the Tendermint /status endpoint.
+type TendermintStatus struct {
+	Result struct {
+		SyncInfo struct {
+			LatestBlockHeight string `json:"latest_block_height"`
+			CatchingUp        bool   `json:"catching_up"`
+		} `json:"sync_info"`
+	} `json:"result"`
+}
+
+// TendermintNetInfo is the response of the Tendermint /net_info endpoint.
+type TendermintNetInfo struct {
+	Result struct {
+		NPeers string `json:"n_peers"`
+	} `json:"result"`
+}
+
+// TendermintConsensusState is the response of the Tendermint /consensus_state
+// endpoint.
+type TendermintConsensusState struct {
+	Result struct {
+		RoundState struct {
+			HeightRoundStep string `json:"height/round/step"`
+		} `json:"round_state"`
+	} `json:"result"`
+}
+
+// TendermintDumpConsensusState is the response of the Tendermint
+// /dump_consensus_state endpoint. The round and step are strings in some
+// Tendermint versions and numbers in others.
+type TendermintDumpConsensusState struct {
+	Result struct {
+		RoundState struct {
+			Height json.RawMessage `json:"height"`
+			Round  json.RawMessage `json:"round"`
+			Step   json.RawMessage `json:"step"`
+		} `json:"round_state"`
+	} `json:"result"`
+}
+
+// TendermintUnconfirmedTxs is the response of the Tendermint
+// /num_unconfirmed_txs endpoint.
+type TendermintUnconfirmedTxs struct {
+	Result struct {
+		NTxs       string `json:"n_txs"`
+		Total      string `json:"total"`
+		TotalBytes string `json:"total_bytes"`
+	} `json:"result"`
+}
+
+// TendermintRoundState is the consensus round state of a Tendermint node.
+type TendermintRoundState struct {
+	Height uint64
+	Round  uint64
+	Step   uint64
+
+	// StepDuration is how long the node has been in the current step.
+	StepDuration time.Duration
+}
+
+// HeimdallConsensus is the consensus health of a Heimdall node. Fields are nil
+// if their Tendermint endpoint couldn't be queried.
+type HeimdallConsensus struct {
+	CatchingUp   *bool
+	Peers        *uint64
+	RoundState   *TendermintRoundState
+	MempoolTxs   *uint64
+	MempoolBytes *uint64
+}
+
+type HeimdallConsensusObserver struct {
+	catchingUp   *prometheus.GaugeVec
+	peers        *prometheus.GaugeVec
+	height       *prometheus.GaugeVec
+	round        *prometheus.GaugeVec
+	step         *prometheus.GaugeVec
+	stepDuration *prometheus.GaugeVec
+	mempoolTxs   *prometheus.GaugeVec
+	mempoolBytes *prometheus.GaugeVec
+}
+
+func (o *HeimdallConsensusObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.HeimdallConsensus, o)
+
+	o.catchingUp = metrics.NewGauge(metrics.Heimdall, "catching_up", "Whether the node is catching up (1) or not (0)")
+	o.peers = metrics.NewGauge(metrics.Heimdall, "peers", "The number of peers connected to the node")
+	o.height = metrics.NewGauge(metrics.Heimdall, "consensus_height", "The height the node is reaching consensus on")
+	o.round = metrics.NewGauge(metrics.Heimdall, "consensus_round", "The consensus round of the current height")
+	o.step = metrics.NewGauge(
+		metrics.Heimdall,
+		"consensus_step",
+		"The consensus step of the current round (1 new height, 2 new round, 3 propose, 4 prevote, 5 prevote wait, 6 precommit, 7 precommit wait, 8 commit)",
+	)
+	o.stepDuration = metrics.NewGauge(metrics.Heimdall, "consensus_step_duration", "The time (in seconds) the node has been in the current consensus step")
+	o.mempoolTxs = metrics.NewGauge(metrics.Heimdall, "mempool_txs", "The number of unconfirmed transactions in the mempool")
+	o.mempoolBytes = metrics.NewGauge(metrics.Heimdall, "mempool_bytes", "The total size (in bytes) of unconfirmed transactions in the mempool")
+}
+
+func (o *HeimdallConsensusObserver) Notify(ctx context.Context, m Message) {
+	consensus := m.Data().(*HeimdallConsensus)
+
+	if consensus.CatchingUp != nil {
+		var catchingUp float64
+		if *consensus.CatchingUp {
+			catchingUp = 1
+		}
+		o.catchingUp.WithLabelValues(m.Network().GetName(), m.Provider()).Set(catchingUp)
+	}
+
+	if consensus.Peers != nil {
+		o.peers.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(*consensus.Peers))
+	}
+
+	if rs := consensus.RoundState; rs != nil {
+		o.height.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(rs.Height))
+		o.round.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(rs.Round))
+		o.step.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(rs.Step))
+		o.stepDuration.WithLabelValues(m.Network().GetName(), m.Provider()).Set(rs.StepDuration.Seconds())
+	}
+
+	if consensus.MempoolTxs != nil {
+		o.mempoolTxs.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(*consensus.MempoolTxs))
+	}
+
+	if consensus.MempoolBytes != nil {
+		o.mempoolBytes.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(*consensus.MempoolBytes))
+	}
+}
+
+func (o *HeimdallConsensusObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		o.catchingUp,
+		o.peers,
+		o.height,
+		o.round,
+		o.step,
+		o.stepDuration,
+		o.mempoolTxs,
+		o.mempoolBytes,
+	}
 }
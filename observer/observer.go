@@ -5,6 +5,7 @@ import (
 	"math"
 	"reflect"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -96,6 +97,17 @@ type Topic interface {
 type EventBus struct {
 	observers map[string]ObserverSet
 	jobs      chan struct{}
+
+	// pending tracks the notifications that are still running for each network
+	// and provider, so that their series are only deleted after them.
+	mu      sync.Mutex
+	pending map[series]*sync.WaitGroup
+}
+
+// series identifies the series of a network and provider.
+type series struct {
+	network  string
+	provider string
 }
 
 // Subscribe configures the given observer to be notified whenever the given
@@ -112,15 +124,80 @@ func (eb *EventBus) Publish(ctx context.Context, topic Topic, m Message) {
 		log.Warn().Str("topic", topic.String()).Msg("Topic published to empty subscriber set")
 	}
 
+	wg := eb.getPending(m.Network(), m.Provider())
+
 	for _, s := range eb.observers[topic.String()] {
 		eb.jobs <- struct{}{}
+		wg.Add(1)
 		go func(o Observer) {
+			defer wg.Done()
 			o.Notify(ctx, m)
 			<-eb.jobs
 		}(s)
 	}
 }
 
+func (eb *EventBus) getPending(n network.Network, provider string) *sync.WaitGroup {
+	key := series{provider: provider}
+	if n != nil {
+		key.network = n.GetName()
+	}
+
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	wg, ok := eb.pending[key]
+	if !ok {
+		wg = new(sync.WaitGroup)
+		eb.pending[key] = wg
+	}
+
+	return wg
+}
+
+// DeleteSeries deletes the series of every subscribed observer that belong to
+// the network and provider. This is used when a provider is stopped so its
+// series don't linger. The provider must not publish anymore, and its running
+// notifications are waited for so they don't recreate the series.
+func (eb *EventBus) DeleteSeries(n network.Network, provider string) {
+	eb.getPending(n, provider).Wait()
+
+	eb.mu.Lock()
+	delete(eb.pending, series{network: n.GetName(), provider: provider})
+	eb.mu.Unlock()
+
+	eb.deleteSeries(prometheus.Labels{"network": n.GetName(), "provider": provider})
+}
+
+// DeleteMatchingSeries deletes the series of every subscribed observer that
+// belong to the network and provider and also match the labels. This is used
+// by meta-providers that split a network's series by another label, such as the
+// rollup ID, and stop publishing only some of them.
+func (eb *EventBus) DeleteMatchingSeries(n network.Network, provider string, labels prometheus.Labels) {
+	eb.getPending(n, provider).Wait()
+
+	matching := prometheus.Labels{"network": n.GetName(), "provider": provider}
+	for k, v := range labels {
+		matching[k] = v
+	}
+
+	eb.deleteSeries(matching)
+}
+
+func (eb *EventBus) deleteSeries(labels prometheus.Labels) {
+	for _, observers := range eb.observers {
+		for _, o := range observers {
+			for _, c := range o.GetCollectors() {
+				if vec, ok := c.(interface {
+					DeletePartialMatch(prometheus.Labels) int
+				}); ok {
+					vec.DeletePartialMatch(labels)
+				}
+			}
+		}
+	}
+}
+
 func (eb *EventBus) Jobs() int {
 	return len(eb.jobs)
 }
@@ -130,43 +207,61 @@ func NewEventBus() *EventBus {
 	return &EventBus{
 		observers: make(map[string]ObserverSet),
 		jobs:      make(chan struct{}, 1024),
+		pending:   make(map[series]*sync.WaitGroup),
 	}
 }
 
 var observersMap = map[string]Observer{
 	"account_balances":                    new(AccountBalancesObserver),
 	"base_fee_per_gas":                    new(BaseFeePerGasObserver),
+	"batch_pipeline":                      new(BatchPipelineObserver),
 	"block":                               new(BlockObserver),
 	"block_interval":                      new(BlockIntervalObserver),
 	"bogon_block":                         new(BogonBlockObserver),
+	"bor_span":                            new(BorSpanObserver),
 	"bridge_event":                        new(BridgeEventObserver),
+	"bridge_reconciliation":               new(BridgeReconciliationObserver),
+	"bridge_token":                        new(BridgeTokenObserver),
 	"checkpoint":                          new(CheckpointObserver),
+	"checkpoint_participation":            new(CheckpointParticipationObserver),
 	"claim_event":                         new(ClaimEventObserver),
+	"contract_call":                       new(ContractCallObserver),
 	"deposit_counts":                      new(DepositCountObserver),
 	"double_sign":                         new(DoubleSignObserver),
 	"empty_block":                         new(EmptyBlockObserver),
+	"erc20_balances":                      new(ERC20BalancesObserver),
 	"exchange_rates":                      new(ExchangeRatesObserver),
+	"exit_root_consistency":               new(ExitRootConsistencyObserver),
 	"exit_roots":                          new(ExitRootsObserver),
 	"finalized_height":                    new(FinalizedHeightObserver),
 	"gas_limit":                           new(GasLimitObserver),
+	"gas_price_oracle":                    new(GasPriceOracleObserver),
 	"gas_used":                            new(GasUsedObserver),
+	"global_exit_root_sync":               new(GlobalExitRootSyncObserver),
 	"hash_divergence":                     new(HashDivergenceObserver),
 	"heimdall_block":                      new(HeimdallBlockObserver),
 	"heimdall_block_interval":             new(HeimdallBlockIntervalObserver),
 	"heimdall_checkpoint":                 new(HeimdallCheckpointObserver),
+	"heimdall_consensus":                  new(HeimdallConsensusObserver),
+	"heimdall_endpoint":                   new(HeimdallEndpointObserver),
 	"heimdall_missed_block_proposal":      new(HeimdallMissedBlockProposalObserver),
 	"heimdall_missed_checkpoint_proposal": new(HeimdallMissedCheckpointProposalObserver),
 	"heimdall_missed_milestone_proposal":  new(HeimdallMissedMilestoneProposal),
 	"heimdall_signature_count":            new(HeimdallSignatureCountObserver),
 	"milestone":                           new(MilestoneObserver),
 	"missed_block_proposal":               new(MissedBlockProposalObserver),
+	"op_balances":                         new(OPBalancesObserver),
+	"op_output_proposal":                  new(OPOutputProposalObserver),
+	"op_sync_status":                      new(OPSyncStatusObserver),
 	"refresh_state_time":                  new(RefreshStateTimeObserver),
 	"reorg":                               new(ReorgObserver),
+	"rollup_economics":                    new(RollupEconomicsObserver),
 	"sealed_out_of_turn":                  new(SealedOutOfTurnObserver),
 	"sensor_block_events":                 new(BlockEventsObserver),
 	"sensor_blocks":                       new(SensorBlocksObserver),
 	"sensor_bogon_block":                  new(SensorBogonBlockObserver),
 	"state_sync":                          new(StateSyncObserver),
+	"state_sync_latency":                  new(StateSyncLatencyObserver),
 	"stolen_block":                        new(StolenBlockObserver),
 	"system":                              new(SystemObserver),
 	"time_to_finalized":                   new(TimeToFinalizedObserver),
@@ -181,10 +276,15 @@ var observersMap = map[string]Observer{
 	"transaction_value":                   new(TransactionValueObserver),
 	"trusted_batch":                       new(TrustedBatchObserver),
 	"uncles":                              new(UnclesObserver),
+	"validator_cache":                     new(ValidatorCacheObserver),
+	"validator_scorecard":                 new(ValidatorScorecardObserver),
+	"validator_set":                       new(ValidatorSetObserver),
+	"validator_set_change":                new(ValidatorSetChangeObserver),
 	"validator_wallet_balance":            new(ValidatorWalletBalanceObserver),
 	"zkevm_batches":                       new(ZkEVMBatchObserver),
 	"rollup_manager":                      new(RollupManagerObserver),
 	"span":                                new(HeimdallSpanObserver),
+	"span_producer_change":                new(HeimdallSpanProducerChangeObserver),
 }
 
 func GetEnabledObserverSet() ObserverSet {
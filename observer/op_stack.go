@@ -0,0 +1,179 @@
+package observer
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/0xPolygon/panoptichain/metrics"
+	"github.com/0xPolygon/panoptichain/observer/topics"
+)
+
+// The L2 heads of an OP Stack rollup node.
+const (
+	OPUnsafeHead    = "unsafe"
+	OPSafeHead      = "safe"
+	OPFinalizedHead = "finalized"
+)
+
+// OPSyncStatus is the sync status returned by optimism_syncStatus. The L1
+// origin lag is the number of L1 blocks between the L1 head and the L1 origin
+// of the unsafe L2 head.
+type OPSyncStatus struct {
+	L2Heads     map[string]uint64
+	L1Head      uint64
+	L1OriginLag uint64
+}
+
+type OPSyncStatusObserver struct {
+	l2Head      *prometheus.GaugeVec
+	l1Head      *prometheus.GaugeVec
+	l1OriginLag *prometheus.GaugeVec
+}
+
+func (o *OPSyncStatusObserver) Notify(ctx context.Context, m Message) {
+	status := m.Data().(*OPSyncStatus)
+
+	for head, number := range status.L2Heads {
+		o.l2Head.WithLabelValues(m.Network().GetName(), m.Provider(), head).Set(float64(number))
+	}
+
+	o.l1Head.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(status.L1Head))
+	o.l1OriginLag.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(status.L1OriginLag))
+}
+
+func (o *OPSyncStatusObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.OPSyncStatus, o)
+
+	o.l2Head = metrics.NewGauge(
+		metrics.RPC,
+		"op_l2_head",
+		"The L2 block number of the rollup node's unsafe, safe, and finalized heads",
+		"head",
+	)
+	o.l1Head = metrics.NewGauge(
+		metrics.RPC,
+		"op_l1_head",
+		"The L1 block number of the rollup node's L1 head",
+	)
+	o.l1OriginLag = metrics.NewGauge(
+		metrics.RPC,
+		"op_l1_origin_lag",
+		"The number of L1 blocks between the L1 head and the L1 origin of the unsafe L2 head",
+	)
+}
+
+func (o *OPSyncStatusObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.l2Head, o.l1Head, o.l1OriginLag}
+}
+
+// OPOutputProposals are the output proposals submitted to the L2 output oracle
+// or the dispute game factory on L1.
+type OPOutputProposals struct {
+	// Latest is the index of the latest output proposal, which is the number
+	// of output proposals minus one.
+	Latest uint64
+
+	// L2Block is the L2 block number of the latest output proposal. This is
+	// only set for the L2 output oracle.
+	L2Block *uint64
+
+	// Time is the L1 time of the latest output proposal.
+	Time time.Time
+
+	// Observed is the number of output proposals since the last refresh.
+	Observed uint64
+}
+
+type OPOutputProposalObserver struct {
+	latest          *prometheus.GaugeVec
+	l2Block         *prometheus.GaugeVec
+	timeSinceLatest *prometheus.GaugeVec
+	observed        *prometheus.CounterVec
+}
+
+func (o *OPOutputProposalObserver) Notify(ctx context.Context, m Message) {
+	proposals := m.Data().(*OPOutputProposals)
+
+	o.latest.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(proposals.Latest))
+	o.timeSinceLatest.WithLabelValues(m.Network().GetName(), m.Provider()).Set(time.Since(proposals.Time).Seconds())
+	o.observed.WithLabelValues(m.Network().GetName(), m.Provider()).Add(float64(proposals.Observed))
+
+	if proposals.L2Block != nil {
+		o.l2Block.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(*proposals.L2Block))
+	}
+}
+
+func (o *OPOutputProposalObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.OPOutputProposals, o)
+
+	o.latest = metrics.NewGauge(
+		metrics.RPC,
+		"op_latest_output_index",
+		"The index of the latest output proposal",
+	)
+	o.l2Block = metrics.NewGauge(
+		metrics.RPC,
+		"op_latest_output_l2_block",
+		"The L2 block number of the latest output proposal",
+	)
+	o.timeSinceLatest = metrics.NewGauge(
+		metrics.RPC,
+		"op_time_since_latest_output",
+		"The time since the latest output proposal (in seconds)",
+	)
+	o.observed = metrics.NewCounter(
+		metrics.RPC,
+		"op_observed_outputs",
+		"The number of output proposals observed",
+	)
+}
+
+func (o *OPOutputProposalObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.latest, o.l2Block, o.timeSinceLatest, o.observed}
+}
+
+// The roles of the OP Stack accounts that pay for L1 transactions.
+const (
+	OPBatcher  = "batcher"
+	OPProposer = "proposer"
+)
+
+// OPBalance is the L1 ETH balance of an OP Stack batcher or proposer.
+type OPBalance struct {
+	Role    string
+	Address common.Address
+	Balance *big.Int
+}
+
+type OPBalancesObserver struct {
+	balance *prometheus.GaugeVec
+}
+
+func (o *OPBalancesObserver) Notify(ctx context.Context, m Message) {
+	balances := m.Data().([]OPBalance)
+
+	for _, b := range balances {
+		balance, _ := b.Balance.Float64()
+		o.balance.WithLabelValues(m.Network().GetName(), m.Provider(), b.Role, b.Address.Hex()).Set(balance)
+	}
+}
+
+func (o *OPBalancesObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.OPBalances, o)
+
+	o.balance = metrics.NewGauge(
+		metrics.RPC,
+		"op_account_balance",
+		"The L1 balance of the batcher and proposer (wei)",
+		"role",
+		"address",
+	)
+}
+
+func (o *OPBalancesObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.balance}
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"math/big"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/0xPolygon/panoptichain/api"
 	"github.com/0xPolygon/panoptichain/contracts"
 	"github.com/0xPolygon/panoptichain/metrics"
+	"github.com/0xPolygon/panoptichain/network"
 	"github.com/0xPolygon/panoptichain/observer/topics"
 )
 
@@ -151,6 +153,17 @@ func (o *BogonBlockObserver) Notify(ctx context.Context, m Message) {
 		return
 	}
 
+	// Unknown signers force a validator set refresh in case the signer was
+	// rotated, and are treated as bogons if it fails.
+	_, ok, err := api.Signer(m.Network(), signer)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to get signer validator")
+	}
+
+	if ok {
+		return
+	}
+
 	logger.Debug().
 		Uint64("block_number", block.NumberU64()).
 		Str("signer", signer).
@@ -220,6 +233,82 @@ func (o *StateSyncObserver) GetCollectors() []prometheus.Collector {
 	return []prometheus.Collector{o.stateSyncID, o.timeSinceLastStateSync}
 }
 
+// StateSyncEvents are the state syncs sent on L1 or committed on Bor within a
+// provider's block range, keyed by state ID with the block time.
+type StateSyncEvents struct {
+	// Network is the Polygon PoS network the state syncs belong to.
+	Network   network.Network
+	Sent      map[uint64]time.Time
+	Committed map[uint64]time.Time
+
+	// LatestSentID is the StateSender counter on L1 and LatestCommittedID is
+	// the StateReceiver lastStateId on Bor. Only one of them is set.
+	LatestSentID      *uint64
+	LatestCommittedID *uint64
+}
+
+// StateSyncLatency is the correlation of L1 and Bor state syncs of a Polygon
+// PoS network.
+type StateSyncLatency struct {
+	// Latencies are the times between the new state syncs being sent on L1 and
+	// committed on Bor.
+	Latencies []time.Duration
+
+	// Backlog is the number of state syncs sent on L1 but not yet committed on
+	// Bor, if both sides are known.
+	Backlog *uint64
+
+	// OldestPending is the age of the oldest state sync that was sent but not
+	// yet committed, or zero if there are none.
+	OldestPending time.Duration
+	Stuck         bool
+}
+
+type StateSyncLatencyObserver struct {
+	latency       *prometheus.HistogramVec
+	backlog       *prometheus.GaugeVec
+	oldestPending *prometheus.GaugeVec
+	stuck         *prometheus.GaugeVec
+}
+
+func (o *StateSyncLatencyObserver) Notify(ctx context.Context, m Message) {
+	data := m.Data().(*StateSyncLatency)
+
+	for _, latency := range data.Latencies {
+		o.latency.WithLabelValues(m.Network().GetName(), m.Provider()).Observe(latency.Seconds())
+	}
+
+	if data.Backlog != nil {
+		o.backlog.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(*data.Backlog))
+	}
+
+	var stuck float64
+	if data.Stuck {
+		stuck = 1
+	}
+
+	o.oldestPending.WithLabelValues(m.Network().GetName(), m.Provider()).Set(data.OldestPending.Seconds())
+	o.stuck.WithLabelValues(m.Network().GetName(), m.Provider()).Set(stuck)
+}
+
+func (o *StateSyncLatencyObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.StateSyncLatency, o)
+
+	o.latency = metrics.NewHistogram(
+		metrics.RPC,
+		"state_sync_latency",
+		"The time (in seconds) between a state sync being sent on L1 and committed on Bor",
+		newExponentialBuckets(2, 13),
+	)
+	o.backlog = metrics.NewGauge(metrics.RPC, "state_sync_backlog", "The number of state syncs sent on L1 but not yet committed on Bor")
+	o.oldestPending = metrics.NewGauge(metrics.RPC, "state_sync_oldest_pending_age", "The age (in seconds) of the oldest state sync not yet committed on Bor")
+	o.stuck = metrics.NewGauge(metrics.RPC, "state_sync_stuck", "Whether a state sync has been pending for longer than the stuck threshold (1) or not (0)")
+}
+
+func (o *StateSyncLatencyObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.latency, o.backlog, o.oldestPending, o.stuck}
+}
+
 type BlockIntervalObserver struct {
 	blockInterval *prometheus.HistogramVec
 }
@@ -649,6 +738,64 @@ func (o *ValidatorWalletBalanceObserver) GetCollectors() []prometheus.Collector
 	return []prometheus.Collector{o.balances}
 }
 
+// CheckpointParticipation compares the signers of a checkpoint with the active
+// validator set.
+type CheckpointParticipation struct {
+	HeaderBlockID *big.Int
+	SignedPower   uint64
+	TotalPower    uint64
+	NonSigners    []api.Validator
+}
+
+type CheckpointParticipationObserver struct {
+	signedStake      *prometheus.GaugeVec
+	nonSigners       *prometheus.GaugeVec
+	missedSignatures *prometheus.CounterVec
+}
+
+func (o *CheckpointParticipationObserver) Notify(ctx context.Context, m Message) {
+	data := m.Data().(*CheckpointParticipation)
+
+	if data.TotalPower > 0 {
+		percent := float64(data.SignedPower) / float64(data.TotalPower) * 100
+		o.signedStake.WithLabelValues(m.Network().GetName(), m.Provider()).Set(percent)
+	}
+
+	o.nonSigners.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(len(data.NonSigners)))
+
+	for _, validator := range data.NonSigners {
+		id := fmt.Sprint(validator.GetID())
+		signer := common.HexToAddress(validator.GetSigner()).Hex()
+		o.missedSignatures.WithLabelValues(m.Network().GetName(), m.Provider(), id, signer).Inc()
+	}
+}
+
+func (o *CheckpointParticipationObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.CheckpointParticipation, o)
+
+	o.signedStake = metrics.NewGauge(
+		metrics.RPC,
+		"checkpoint_signed_stake_percent",
+		"The percentage of active validator stake that signed the latest checkpoint",
+	)
+	o.nonSigners = metrics.NewGauge(
+		metrics.RPC,
+		"checkpoint_non_signers",
+		"The number of active validators that did not sign the latest checkpoint",
+	)
+	o.missedSignatures = metrics.NewCounter(
+		metrics.RPC,
+		"missed_checkpoint_signature",
+		"Counts the number of times an active validator did not sign a checkpoint",
+		"validator_id",
+		"signer",
+	)
+}
+
+func (o *CheckpointParticipationObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.signedStake, o.nonSigners, o.missedSignatures}
+}
+
 type MissedBlockProposal map[uint64][]string
 
 type MissedBlockProposalObserver struct {
@@ -686,6 +833,63 @@ func (o *MissedBlockProposalObserver) GetCollectors() []prometheus.Collector {
 	return []prometheus.Collector{o.counter}
 }
 
+// BorSpan is the span containing the latest Bor block and whether the block
+// authors were selected producers of their span.
+type BorSpan struct {
+	ID              uint64
+	BlocksRemaining uint64
+
+	// Author is the signer of the latest block. It is empty if the signer
+	// couldn't be recovered.
+	Author       string
+	AuthorInSpan bool
+
+	// OutOfSpanSigners are the signers of the new blocks that aren't producers
+	// of the span containing the block, one entry per block.
+	OutOfSpanSigners []string
+}
+
+type BorSpanObserver struct {
+	blocksRemaining *prometheus.GaugeVec
+	authorInSpan    *prometheus.GaugeVec
+	outOfSpanBlocks *prometheus.CounterVec
+}
+
+func (o *BorSpanObserver) Notify(ctx context.Context, m Message) {
+	span := m.Data().(*BorSpan)
+
+	o.blocksRemaining.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(span.BlocksRemaining))
+
+	if len(span.Author) > 0 {
+		var inSpan float64
+		if span.AuthorInSpan {
+			inSpan = 1
+		}
+		o.authorInSpan.WithLabelValues(m.Network().GetName(), m.Provider()).Set(inSpan)
+	}
+
+	for _, signer := range span.OutOfSpanSigners {
+		o.outOfSpanBlocks.WithLabelValues(m.Network().GetName(), m.Provider(), signer).Inc()
+	}
+}
+
+func (o *BorSpanObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.BorSpan, o)
+
+	o.blocksRemaining = metrics.NewGauge(metrics.RPC, "span_blocks_remaining", "The number of blocks remaining until the current span ends")
+	o.authorInSpan = metrics.NewGauge(metrics.RPC, "span_author_in_producers", "Whether the latest block author is a selected producer of the current span (1) or not (0)")
+	o.outOfSpanBlocks = metrics.NewCounter(
+		metrics.RPC,
+		"out_of_span_blocks",
+		"Blocks authored by a signer that isn't a selected producer of the span",
+		"signer_address",
+	)
+}
+
+func (o *BorSpanObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.blocksRemaining, o.authorInSpan, o.outOfSpanBlocks}
+}
+
 type TransactionPool struct {
 	Pending uint64
 	Queued  uint64
@@ -823,6 +1027,109 @@ func (o *ZkEVMBatchObserver) GetCollectors() []prometheus.Collector {
 	}
 }
 
+// TrustedBatchTimes are the times the new trusted batches of an L2 network were
+// first seen.
+type TrustedBatchTimes struct {
+	ChainID uint64
+	Last    uint64
+	Times   map[uint64]time.Time
+}
+
+// BatchStageEvent is a rollup manager event that virtualized or verified the
+// batches up to the batch number.
+type BatchStageEvent struct {
+	Batch uint64
+	Time  time.Time
+}
+
+// RollupBatchStage is the sequenced and verified batch events of a rollup
+// within a provider's block range.
+type RollupBatchStage struct {
+	ChainID            *uint64
+	LastBatchSequenced *uint64
+	LastVerifiedBatch  *uint64
+	Sequenced          []BatchStageEvent
+	Verified           []BatchStageEvent
+}
+
+// RollupBatchStages maps the rollup ID to its batch events.
+type RollupBatchStages map[uint32]*RollupBatchStage
+
+// BatchPipeline is the time a rollup's batches took to move between stages.
+type BatchPipeline struct {
+	RollupID          uint32
+	TrustedToVirtual  []time.Duration
+	VirtualToVerified []time.Duration
+
+	// PendingVirtualization is nil when the rollup's trusted batches aren't
+	// monitored.
+	PendingVirtualization *uint64
+	PendingVerification   *uint64
+}
+
+type BatchPipelineObserver struct {
+	trustedToVirtual      *prometheus.HistogramVec
+	virtualToVerified     *prometheus.HistogramVec
+	pendingVirtualization *prometheus.GaugeVec
+	pendingVerification   *prometheus.GaugeVec
+}
+
+func (o *BatchPipelineObserver) Notify(ctx context.Context, m Message) {
+	data := m.Data().(*BatchPipeline)
+	labels := []string{m.Network().GetName(), m.Provider(), fmt.Sprint(data.RollupID)}
+
+	for _, latency := range data.TrustedToVirtual {
+		o.trustedToVirtual.WithLabelValues(labels...).Observe(latency.Seconds())
+	}
+
+	for _, latency := range data.VirtualToVerified {
+		o.virtualToVerified.WithLabelValues(labels...).Observe(latency.Seconds())
+	}
+
+	if data.PendingVirtualization != nil {
+		o.pendingVirtualization.WithLabelValues(labels...).Set(float64(*data.PendingVirtualization))
+	}
+
+	if data.PendingVerification != nil {
+		o.pendingVerification.WithLabelValues(labels...).Set(float64(*data.PendingVerification))
+	}
+}
+
+func (o *BatchPipelineObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.BatchPipeline, o)
+
+	o.trustedToVirtual = metrics.NewHistogram(
+		metrics.RPC,
+		"batch_trusted_to_virtual_latency",
+		"The time (in seconds) between a batch being first seen trusted and being sequenced on L1",
+		newExponentialBuckets(2, 15),
+		"rollup",
+	)
+	o.virtualToVerified = metrics.NewHistogram(
+		metrics.RPC,
+		"batch_virtual_to_verified_latency",
+		"The time (in seconds) between a batch being sequenced and verified on L1",
+		newExponentialBuckets(2, 17),
+		"rollup",
+	)
+	o.pendingVirtualization = metrics.NewGauge(
+		metrics.RPC,
+		"batches_pending_virtualization",
+		"The number of trusted batches not yet sequenced on L1",
+		"rollup",
+	)
+	o.pendingVerification = metrics.NewGauge(
+		metrics.RPC,
+		"batches_pending_verification",
+		"The number of sequenced batches not yet verified on L1",
+		"rollup",
+	)
+}
+
+func (o *BatchPipelineObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.trustedToVirtual, o.virtualToVerified, o.pendingVirtualization, o.pendingVerification}
+}
+
 type ExitRoot struct {
 	Hash common.Hash
 	Time time.Time
@@ -919,6 +1226,159 @@ func (o *ExitRootsObserver) GetCollectors() []prometheus.Collector {
 	}
 }
 
+// GlobalExitRoots are the global exit roots updated on L1 within a provider's
+// block range, or used by the L2 batches or found in the L2 GlobalExitRootL2
+// contract.
+type GlobalExitRoots []ExitRoot
+
+// The exit roots checked for consistency.
+const (
+	MainnetExitRoot = "mainnet"
+	RollupExitRoot  = "rollup"
+	LocalExitRoot   = "local"
+)
+
+// ExitRootConsistency is whether an exit root matches its source. The mainnet
+// exit root is compared to the L1 bridge's local exit root, the rollup exit
+// root to the rollup manager's, and the local exit root to the L2 bridge's.
+type ExitRootConsistency struct {
+	Root string
+
+	// Consistent is nil when the roots can't be compared because of pending
+	// deposits.
+	Consistent *bool
+
+	// PendingDeposits is the number of bridge deposits that haven't been
+	// pushed to the global exit root manager.
+	PendingDeposits *uint32
+}
+
+type ExitRootConsistencies []ExitRootConsistency
+
+type ExitRootConsistencyObserver struct {
+	consistent      *prometheus.GaugeVec
+	inconsistencies *prometheus.CounterVec
+	pendingDeposits *prometheus.GaugeVec
+}
+
+func (o *ExitRootConsistencyObserver) Notify(ctx context.Context, m Message) {
+	data := m.Data().(ExitRootConsistencies)
+
+	for _, c := range data {
+		if c.PendingDeposits != nil {
+			o.pendingDeposits.WithLabelValues(m.Network().GetName(), m.Provider(), c.Root).Set(float64(*c.PendingDeposits))
+		}
+
+		if c.Consistent == nil {
+			continue
+		}
+
+		consistent := 0.0
+		if *c.Consistent {
+			consistent = 1
+		} else {
+			o.inconsistencies.WithLabelValues(m.Network().GetName(), m.Provider(), c.Root).Inc()
+		}
+		o.consistent.WithLabelValues(m.Network().GetName(), m.Provider(), c.Root).Set(consistent)
+	}
+}
+
+func (o *ExitRootConsistencyObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.ExitRootConsistency, o)
+
+	o.consistent = metrics.NewGauge(
+		metrics.RPC,
+		"exit_root_consistent",
+		"Whether the exit root matches its source contract",
+		"root",
+	)
+	o.inconsistencies = metrics.NewCounter(
+		metrics.RPC,
+		"exit_root_inconsistencies",
+		"The number of times the exit root didn't match its source contract",
+		"root",
+	)
+	o.pendingDeposits = metrics.NewGauge(
+		metrics.RPC,
+		"exit_root_pending_deposits",
+		"The number of bridge deposits not yet included in the exit root",
+		"root",
+	)
+}
+
+func (o *ExitRootConsistencyObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.consistent, o.inconsistencies, o.pendingDeposits}
+}
+
+// GlobalExitRootSync is how long the L1 global exit roots took to be used on an
+// L2 network.
+type GlobalExitRootSync struct {
+	// L1Network is the name of the network the global exit roots are from.
+	L1Network string
+
+	Lags          []time.Duration
+	Pending       int
+	OldestPending time.Duration
+
+	// Violations is the number of new global exit roots that weren't used on
+	// L2 within the SLA.
+	Violations uint64
+}
+
+type GlobalExitRootSyncObserver struct {
+	lag           *prometheus.HistogramVec
+	pending       *prometheus.GaugeVec
+	oldestPending *prometheus.GaugeVec
+	violations    *prometheus.CounterVec
+}
+
+func (o *GlobalExitRootSyncObserver) Notify(ctx context.Context, m Message) {
+	data := m.Data().(*GlobalExitRootSync)
+	labels := []string{m.Network().GetName(), m.Provider(), data.L1Network}
+
+	for _, lag := range data.Lags {
+		o.lag.WithLabelValues(labels...).Observe(lag.Seconds())
+	}
+
+	o.pending.WithLabelValues(labels...).Set(float64(data.Pending))
+	o.oldestPending.WithLabelValues(labels...).Set(data.OldestPending.Seconds())
+	o.violations.WithLabelValues(labels...).Add(float64(data.Violations))
+}
+
+func (o *GlobalExitRootSyncObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.GlobalExitRootSync, o)
+
+	o.lag = metrics.NewHistogram(
+		metrics.RPC,
+		"global_exit_root_lag",
+		"The time (in seconds) between an L1 global exit root update and its use on L2",
+		newExponentialBuckets(2, 14),
+		"l1_network",
+	)
+	o.pending = metrics.NewGauge(
+		metrics.RPC,
+		"global_exit_root_pending",
+		"The number of L1 global exit roots not yet used on L2",
+		"l1_network",
+	)
+	o.oldestPending = metrics.NewGauge(
+		metrics.RPC,
+		"global_exit_root_oldest_pending",
+		"The age (in seconds) of the oldest L1 global exit root not yet used on L2",
+		"l1_network",
+	)
+	o.violations = metrics.NewCounter(
+		metrics.RPC,
+		"global_exit_root_sla_violations",
+		"The number of L1 global exit roots not used on L2 within the SLA",
+		"l1_network",
+	)
+}
+
+func (o *GlobalExitRootSyncObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.lag, o.pending, o.oldestPending, o.violations}
+}
+
 type DepositCounts struct {
 	DepositCount            *big.Int
 	LastUpdatedDepositCount *uint32
@@ -1034,6 +1494,277 @@ func (o *BridgeEventObserver) GetCollectors() []prometheus.Collector {
 	return []prometheus.Collector{o.timeSinceLastBridgeEvent, o.depositCount, o.amount}
 }
 
+// BridgeDeposit is a BridgeEvent emitted by the bridge of the network the
+// deposit was made on.
+type BridgeDeposit struct {
+	DestinationNetwork uint32
+	DepositCount       uint32
+	LeafType           uint8
+	OriginNetwork      uint32
+	OriginAddress      common.Address
+	Amount             *big.Int
+	Time               time.Time
+}
+
+// IsEther returns whether the deposit is an ether transfer.
+func (d BridgeDeposit) IsEther() bool {
+	return d.LeafType == 0 && d.OriginNetwork == 0 && d.OriginAddress == (common.Address{})
+}
+
+// BridgeClaim is a ClaimEvent emitted by the bridge of the destination network.
+type BridgeClaim struct {
+	GlobalIndex *big.Int
+	Amount      *big.Int
+	Time        time.Time
+}
+
+// BridgeActivity is the bridge deposits and claims of a network within a
+// provider's block range.
+type BridgeActivity struct {
+	// Bridge is the bridge contract address. It identifies the ecosystem the
+	// network belongs to, since every ecosystem numbers its networks from 0.
+	Bridge common.Address
+
+	// NetworkID is the bridge network ID, 0 for L1 and the rollup ID for
+	// rollups.
+	NetworkID    uint32
+	DepositCount *uint32
+	Deposits     []BridgeDeposit
+	Claims       []BridgeClaim
+}
+
+// BridgeUnclaimed is the deposits to a destination network that have been
+// unclaimed for longer than the threshold.
+type BridgeUnclaimed struct {
+	DestinationNetwork uint32
+	Threshold          time.Duration
+	Count              uint64
+
+	// Ether is the total ether (in wei) of the unclaimed ether deposits.
+	// Token deposits are only counted.
+	Ether *big.Int
+}
+
+// BridgeReconciliation is the deposit to claim reconciliation of the deposits
+// made on a network.
+type BridgeReconciliation struct {
+	// Latencies maps the destination network to the times between deposits
+	// and their claims.
+	Latencies map[uint32][]time.Duration
+	Unclaimed []BridgeUnclaimed
+
+	// OrphanClaims maps the destination network to the number of new claims
+	// without a matching deposit.
+	OrphanClaims map[uint32]uint64
+}
+
+type BridgeReconciliationObserver struct {
+	latency        *prometheus.HistogramVec
+	unclaimed      *prometheus.GaugeVec
+	unclaimedEther *prometheus.GaugeVec
+	orphanClaims   *prometheus.CounterVec
+}
+
+func (o *BridgeReconciliationObserver) Notify(ctx context.Context, m Message) {
+	data := m.Data().(*BridgeReconciliation)
+
+	for destination, latencies := range data.Latencies {
+		for _, latency := range latencies {
+			o.latency.WithLabelValues(m.Network().GetName(), m.Provider(), fmt.Sprint(destination)).Observe(latency.Seconds())
+		}
+	}
+
+	// Remove destinations that no longer have unclaimed deposits.
+	labels := prometheus.Labels{"network": m.Network().GetName(), "provider": m.Provider()}
+	o.unclaimed.DeletePartialMatch(labels)
+	o.unclaimedEther.DeletePartialMatch(labels)
+
+	for _, u := range data.Unclaimed {
+		destination := fmt.Sprint(u.DestinationNetwork)
+		threshold := fmt.Sprint(u.Threshold.Seconds())
+		ether, _ := weiToEther(u.Ether).Float64()
+
+		o.unclaimed.WithLabelValues(m.Network().GetName(), m.Provider(), destination, threshold).Set(float64(u.Count))
+		o.unclaimedEther.WithLabelValues(m.Network().GetName(), m.Provider(), destination, threshold).Set(ether)
+	}
+
+	for destination, count := range data.OrphanClaims {
+		o.orphanClaims.WithLabelValues(m.Network().GetName(), m.Provider(), fmt.Sprint(destination)).Add(float64(count))
+	}
+}
+
+func (o *BridgeReconciliationObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.BridgeReconciliation, o)
+
+	o.latency = metrics.NewHistogram(
+		metrics.RPC,
+		"bridge_claim_latency",
+		"The time (in seconds) between a bridge deposit and its claim on the destination network",
+		newExponentialBuckets(2, 17),
+		"destination_network",
+	)
+	o.unclaimed = metrics.NewGauge(
+		metrics.RPC,
+		"bridge_unclaimed_deposits",
+		"The number of bridge deposits unclaimed for longer than the threshold (in seconds)",
+		"destination_network",
+		"older_than",
+	)
+	o.unclaimedEther = metrics.NewGauge(
+		metrics.RPC,
+		"bridge_unclaimed_ether",
+		"The total ether of ether bridge deposits unclaimed for longer than the threshold (in seconds), excluding token deposits",
+		"destination_network",
+		"older_than",
+	)
+	o.orphanClaims = metrics.NewCounter(
+		metrics.RPC,
+		"bridge_orphan_claims",
+		"The number of claims without a matching bridge deposit",
+		"destination_network",
+	)
+}
+
+func (o *BridgeReconciliationObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.latency, o.unclaimed, o.unclaimedEther, o.orphanClaims}
+}
+
+// BridgeToken identifies a bridged token by the network it originates from and
+// its address on that network. Ether is the zero address.
+type BridgeToken struct {
+	OriginNetwork uint32
+	OriginAddress common.Address
+}
+
+// BridgeTokenFlow is the amount of a token deposited from (outflow) and claimed
+// on (inflow) a network within a provider's block range.
+type BridgeTokenFlow struct {
+	BridgeToken
+
+	Symbol     string
+	Inflow     *big.Float // The amount with the token decimals applied
+	Outflow    *big.Float // The amount with the token decimals applied
+	InflowUSD  *float64
+	OutflowUSD *float64
+}
+
+type BridgeTokenFlows []*BridgeTokenFlow
+
+// BridgeLockedBalance is the balance of a token locked in the bridge contract.
+type BridgeLockedBalance struct {
+	Token   common.Address
+	Symbol  string
+	Balance *big.Float // The balance with the token decimals applied
+	USD     *float64
+}
+
+type BridgeLockedBalances []*BridgeLockedBalance
+
+type BridgeTokenObserver struct {
+	inflow           *prometheus.CounterVec
+	outflow          *prometheus.CounterVec
+	inflowUSD        *prometheus.CounterVec
+	outflowUSD       *prometheus.CounterVec
+	lockedBalance    *prometheus.GaugeVec
+	lockedBalanceUSD *prometheus.GaugeVec
+}
+
+func (o *BridgeTokenObserver) Notify(ctx context.Context, m Message) {
+	logger := NewLogger(o, m)
+
+	switch data := m.Data().(type) {
+	case BridgeTokenFlows:
+		for _, flow := range data {
+			labels := []string{m.Network().GetName(), m.Provider(), fmt.Sprint(flow.OriginNetwork), flow.OriginAddress.Hex(), flow.Symbol}
+
+			inflow, _ := flow.Inflow.Float64()
+			o.inflow.WithLabelValues(labels...).Add(inflow)
+
+			outflow, _ := flow.Outflow.Float64()
+			o.outflow.WithLabelValues(labels...).Add(outflow)
+
+			if flow.InflowUSD != nil {
+				o.inflowUSD.WithLabelValues(labels...).Add(*flow.InflowUSD)
+			}
+
+			if flow.OutflowUSD != nil {
+				o.outflowUSD.WithLabelValues(labels...).Add(*flow.OutflowUSD)
+			}
+		}
+
+	case BridgeLockedBalances:
+		for _, b := range data {
+			labels := []string{m.Network().GetName(), m.Provider(), b.Token.Hex(), b.Symbol}
+
+			balance, _ := b.Balance.Float64()
+			o.lockedBalance.WithLabelValues(labels...).Set(balance)
+
+			if b.USD != nil {
+				o.lockedBalanceUSD.WithLabelValues(labels...).Set(*b.USD)
+			}
+		}
+
+	default:
+		logger.Error().Msg("Failed to match any types")
+	}
+}
+
+func (o *BridgeTokenObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.BridgeTokenFlows, o)
+	eb.Subscribe(topics.BridgeLockedBalances, o)
+
+	o.inflow = metrics.NewCounter(
+		metrics.RPC,
+		"bridge_token_inflow",
+		"The amount of the token claimed on the network (in token units)",
+		"origin_network",
+		"origin_token",
+		"symbol",
+	)
+	o.outflow = metrics.NewCounter(
+		metrics.RPC,
+		"bridge_token_outflow",
+		"The amount of the token deposited from the network (in token units)",
+		"origin_network",
+		"origin_token",
+		"symbol",
+	)
+	o.inflowUSD = metrics.NewCounter(
+		metrics.RPC,
+		"bridge_token_inflow_usd",
+		"The amount of the token claimed on the network (in USD)",
+		"origin_network",
+		"origin_token",
+		"symbol",
+	)
+	o.outflowUSD = metrics.NewCounter(
+		metrics.RPC,
+		"bridge_token_outflow_usd",
+		"The amount of the token deposited from the network (in USD)",
+		"origin_network",
+		"origin_token",
+		"symbol",
+	)
+	o.lockedBalance = metrics.NewGauge(
+		metrics.RPC,
+		"bridge_locked_balance",
+		"The balance of the token locked in the bridge contract (in token units)",
+		"token",
+		"symbol",
+	)
+	o.lockedBalanceUSD = metrics.NewGauge(
+		metrics.RPC,
+		"bridge_locked_balance_usd",
+		"The balance of the token locked in the bridge contract (in USD)",
+		"token",
+		"symbol",
+	)
+}
+
+func (o *BridgeTokenObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.inflow, o.outflow, o.inflowUSD, o.outflowUSD, o.lockedBalance, o.lockedBalanceUSD}
+}
+
 type ClaimEventTimes map[uint32]time.Time
 
 type ClaimEventObserver struct {
@@ -1124,6 +1855,12 @@ type RollupTx struct {
 	Address common.Address
 }
 
+// ForcedBatch is a forced batch that hasn't been sequenced yet.
+type ForcedBatch struct {
+	Number uint64
+	Time   time.Time
+}
+
 type RollupData struct {
 	LastBatchSequenced          *uint64
 	LastSequencedTimestamp      *uint64
@@ -1132,6 +1869,11 @@ type RollupData struct {
 
 	LastForceBatch          *uint64
 	LastForceBatchSequenced *uint64
+	ForceBatchTimeout       *uint64
+
+	// PendingForcedBatches are the forced batches that haven't been sequenced
+	// and whose submission time is known.
+	PendingForcedBatches []ForcedBatch
 
 	LastVerifiedBatch          *uint64
 	LastVerifiedTimestamp      *uint64
@@ -1159,8 +1901,12 @@ type RollupManagerObserver struct {
 	verifiedBatchesTxFee       *prometheus.HistogramVec
 	observedVerifiedBatches    *prometheus.CounterVec
 
-	lastForceBatch          *prometheus.GaugeVec
-	lastForceBatchSequenced *prometheus.GaugeVec
+	lastForceBatch             *prometheus.GaugeVec
+	lastForceBatchSequenced    *prometheus.GaugeVec
+	pendingForcedBatches       *prometheus.GaugeVec
+	oldestPendingForcedBatch   *prometheus.GaugeVec
+	forcedBatchesPastTimeout   *prometheus.GaugeVec
+	forcedBatchCensorshipAlert *prometheus.GaugeVec
 
 	chainID *prometheus.GaugeVec
 
@@ -1236,6 +1982,34 @@ func (o *RollupManagerObserver) notifyAggregatorBalances(m Message, balances map
 	}
 }
 
+// notifyForcedBatches updates the forced batch metrics. Censorship is suspected
+// when a forced batch is past the force batch timeout, but the trusted sequencer
+// has sequenced batches since it was submitted without including it.
+func (o *RollupManagerObserver) notifyForcedBatches(m Message, rollup *RollupData, id string) {
+	timeout := time.Duration(*rollup.ForceBatchTimeout) * time.Second
+
+	var oldest time.Duration
+	var past, censored float64
+	for _, batch := range rollup.PendingForcedBatches {
+		age := time.Since(batch.Time)
+		oldest = max(oldest, age)
+
+		if age <= timeout {
+			continue
+		}
+
+		past++
+
+		if rollup.LastSequencedTimestamp != nil && int64(*rollup.LastSequencedTimestamp) > batch.Time.Unix() {
+			censored = 1
+		}
+	}
+
+	o.oldestPendingForcedBatch.WithLabelValues(m.Network().GetName(), m.Provider(), id).Set(oldest.Seconds())
+	o.forcedBatchesPastTimeout.WithLabelValues(m.Network().GetName(), m.Provider(), id).Set(past)
+	o.forcedBatchCensorshipAlert.WithLabelValues(m.Network().GetName(), m.Provider(), id).Set(censored)
+}
+
 func (o *RollupManagerObserver) notifyRollup(m Message, rollup *RollupData, id string) {
 	if rollup.TrustedSequencerBalances.ETH != nil {
 		eth, _ := rollup.TrustedSequencerBalances.ETH.Float64()
@@ -1274,7 +2048,21 @@ func (o *RollupManagerObserver) notifyRollup(m Message, rollup *RollupData, id s
 
 	if rollup.LastForceBatchSequenced != nil {
 		lfbs := float64(*rollup.LastForceBatchSequenced)
-		o.lastForceBatch.WithLabelValues(m.Network().GetName(), m.Provider(), id).Set(lfbs)
+		o.lastForceBatchSequenced.WithLabelValues(m.Network().GetName(), m.Provider(), id).Set(lfbs)
+	}
+
+	// Every forced batch after the last one sequenced is pending, including the
+	// ones whose submission time isn't known.
+	if rollup.LastForceBatch != nil && rollup.LastForceBatchSequenced != nil {
+		var pending float64
+		if *rollup.LastForceBatch > *rollup.LastForceBatchSequenced {
+			pending = float64(*rollup.LastForceBatch - *rollup.LastForceBatchSequenced)
+		}
+		o.pendingForcedBatches.WithLabelValues(m.Network().GetName(), m.Provider(), id).Set(pending)
+	}
+
+	if rollup.ForceBatchTimeout != nil {
+		o.notifyForcedBatches(m, rollup, id)
 	}
 
 	if rollup.LastVerifiedBatch != nil {
@@ -1399,6 +2187,30 @@ func (o *RollupManagerObserver) Register(eb *EventBus) {
 		"The last force batch sequenced number",
 		"rollup",
 	)
+	o.pendingForcedBatches = metrics.NewGauge(
+		metrics.RPC,
+		"zkevm_pending_forced_batches",
+		"The number of forced batches that haven't been sequenced",
+		"rollup",
+	)
+	o.oldestPendingForcedBatch = metrics.NewGauge(
+		metrics.RPC,
+		"zkevm_oldest_pending_forced_batch_age",
+		"The age of the oldest forced batch that hasn't been sequenced (in seconds)",
+		"rollup",
+	)
+	o.forcedBatchesPastTimeout = metrics.NewGauge(
+		metrics.RPC,
+		"zkevm_forced_batches_past_timeout",
+		"The number of forced batches that haven't been sequenced within the force batch timeout",
+		"rollup",
+	)
+	o.forcedBatchCensorshipAlert = metrics.NewGauge(
+		metrics.RPC,
+		"zkevm_forced_batch_censorship_suspected",
+		"Whether the trusted sequencer sequenced batches after a forced batch that is past the force batch timeout (1) or not (0)",
+		"rollup",
+	)
 
 	o.chainID = metrics.NewGauge(
 		metrics.RPC,
@@ -1468,6 +2280,10 @@ func (o *RollupManagerObserver) GetCollectors() []prometheus.Collector {
 
 		o.lastForceBatch,
 		o.lastForceBatchSequenced,
+		o.pendingForcedBatches,
+		o.oldestPendingForcedBatch,
+		o.forcedBatchesPastTimeout,
+		o.forcedBatchCensorshipAlert,
 
 		o.chainID,
 
@@ -1482,24 +2298,249 @@ func (o *RollupManagerObserver) GetCollectors() []prometheus.Collector {
 	}
 }
 
+// RollupBatchCost is the L1 transaction fee of a sequence or verify batches
+// transaction, split evenly across the batches it sequenced or verified.
+type RollupBatchCost struct {
+	RollupID uint32
+	Fee      *big.Int
+	USD      *float64
+}
+
+// AggregatorEconomics is the POL earned and ETH spent by an aggregator
+// verifying batches.
+type AggregatorEconomics struct {
+	Reward    *big.Int
+	RewardUSD *float64
+	Spent     *big.Int
+	SpentUSD  *float64
+}
+
+// RollupEconomics joins the rollup manager's transaction fees, batch rewards,
+// and balances. The time to empty is nil for the accounts that no longer spent
+// ETH within the burn rate window, and their series are deleted.
+type RollupEconomics struct {
+	SequencedBatchCosts []RollupBatchCost
+	VerifiedBatchCosts  []RollupBatchCost
+
+	Aggregators map[common.Address]*AggregatorEconomics
+
+	TrustedSequencerTimeToEmpty map[uint32]*time.Duration
+	AggregatorTimeToEmpty       map[common.Address]*time.Duration
+}
+
+type RollupEconomicsObserver struct {
+	sequencedBatchCost    *prometheus.HistogramVec
+	sequencedBatchCostUSD *prometheus.HistogramVec
+	verifiedBatchCost     *prometheus.HistogramVec
+	verifiedBatchCostUSD  *prometheus.HistogramVec
+
+	aggregatorReward    *prometheus.CounterVec
+	aggregatorRewardUSD *prometheus.CounterVec
+	aggregatorSpent     *prometheus.CounterVec
+	aggregatorSpentUSD  *prometheus.CounterVec
+
+	trustedSequencerTimeToEmpty *prometheus.GaugeVec
+	aggregatorTimeToEmpty       *prometheus.GaugeVec
+}
+
+func (o *RollupEconomicsObserver) Notify(ctx context.Context, m Message) {
+	data := m.Data().(*RollupEconomics)
+	network := m.Network().GetName()
+
+	for _, cost := range data.SequencedBatchCosts {
+		id := fmt.Sprint(cost.RollupID)
+		gwei, _ := weiToGwei(cost.Fee).Float64()
+		o.sequencedBatchCost.WithLabelValues(network, m.Provider(), id).Observe(gwei)
+
+		if cost.USD != nil {
+			o.sequencedBatchCostUSD.WithLabelValues(network, m.Provider(), id).Observe(*cost.USD)
+		}
+	}
+
+	for _, cost := range data.VerifiedBatchCosts {
+		id := fmt.Sprint(cost.RollupID)
+		gwei, _ := weiToGwei(cost.Fee).Float64()
+		o.verifiedBatchCost.WithLabelValues(network, m.Provider(), id).Observe(gwei)
+
+		if cost.USD != nil {
+			o.verifiedBatchCostUSD.WithLabelValues(network, m.Provider(), id).Observe(*cost.USD)
+		}
+	}
+
+	for address, aggregator := range data.Aggregators {
+		reward, _ := weiToGwei(aggregator.Reward).Float64()
+		o.aggregatorReward.WithLabelValues(network, m.Provider(), address.Hex()).Add(reward)
+
+		spent, _ := weiToGwei(aggregator.Spent).Float64()
+		o.aggregatorSpent.WithLabelValues(network, m.Provider(), address.Hex()).Add(spent)
+
+		if aggregator.RewardUSD != nil {
+			o.aggregatorRewardUSD.WithLabelValues(network, m.Provider(), address.Hex()).Add(*aggregator.RewardUSD)
+		}
+
+		if aggregator.SpentUSD != nil {
+			o.aggregatorSpentUSD.WithLabelValues(network, m.Provider(), address.Hex()).Add(*aggregator.SpentUSD)
+		}
+	}
+
+	for id, tte := range data.TrustedSequencerTimeToEmpty {
+		labels := []string{network, m.Provider(), fmt.Sprint(id)}
+		if tte == nil {
+			o.trustedSequencerTimeToEmpty.DeleteLabelValues(labels...)
+			continue
+		}
+
+		o.trustedSequencerTimeToEmpty.WithLabelValues(labels...).Set(tte.Seconds())
+	}
+
+	for address, tte := range data.AggregatorTimeToEmpty {
+		labels := []string{network, m.Provider(), address.Hex()}
+		if tte == nil {
+			o.aggregatorTimeToEmpty.DeleteLabelValues(labels...)
+			continue
+		}
+
+		o.aggregatorTimeToEmpty.WithLabelValues(labels...).Set(tte.Seconds())
+	}
+}
+
+func (o *RollupEconomicsObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.RollupEconomics, o)
+
+	o.sequencedBatchCost = metrics.NewHistogram(
+		metrics.RPC,
+		"zkevm_sequenced_batch_cost",
+		"The L1 transaction fee per sequenced batch (in gwei)",
+		newExponentialBuckets(10, 9),
+		"rollup",
+	)
+	o.sequencedBatchCostUSD = metrics.NewHistogram(
+		metrics.RPC,
+		"zkevm_sequenced_batch_cost_usd",
+		"The L1 transaction fee per sequenced batch (in USD)",
+		newExponentialBuckets(2, 12),
+		"rollup",
+	)
+	o.verifiedBatchCost = metrics.NewHistogram(
+		metrics.RPC,
+		"zkevm_verified_batch_cost",
+		"The L1 transaction fee per verified batch (in gwei)",
+		newExponentialBuckets(10, 9),
+		"rollup",
+	)
+	o.verifiedBatchCostUSD = metrics.NewHistogram(
+		metrics.RPC,
+		"zkevm_verified_batch_cost_usd",
+		"The L1 transaction fee per verified batch (in USD)",
+		newExponentialBuckets(2, 12),
+		"rollup",
+	)
+
+	o.aggregatorReward = metrics.NewCounter(
+		metrics.RPC,
+		"zkevm_aggregator_reward",
+		"The POL rewarded to the aggregator for verifying batches (in gwei)",
+		"address",
+	)
+	o.aggregatorRewardUSD = metrics.NewCounter(
+		metrics.RPC,
+		"zkevm_aggregator_reward_usd",
+		"The POL rewarded to the aggregator for verifying batches (in USD)",
+		"address",
+	)
+	o.aggregatorSpent = metrics.NewCounter(
+		metrics.RPC,
+		"zkevm_aggregator_spent",
+		"The ETH spent by the aggregator on verify batches transactions (in gwei)",
+		"address",
+	)
+	o.aggregatorSpentUSD = metrics.NewCounter(
+		metrics.RPC,
+		"zkevm_aggregator_spent_usd",
+		"The ETH spent by the aggregator on verify batches transactions (in USD)",
+		"address",
+	)
+
+	o.trustedSequencerTimeToEmpty = metrics.NewGauge(
+		metrics.RPC,
+		"zkevm_trusted_sequencer_time_to_empty",
+		"The projected time until the trusted sequencer ETH balance is empty at the current burn rate (in seconds)",
+		"rollup",
+	)
+	o.aggregatorTimeToEmpty = metrics.NewGauge(
+		metrics.RPC,
+		"zkevm_aggregator_time_to_empty",
+		"The projected time until the aggregator ETH balance is empty at the current burn rate (in seconds)",
+		"address",
+	)
+}
+
+func (o *RollupEconomicsObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		o.sequencedBatchCost,
+		o.sequencedBatchCostUSD,
+		o.verifiedBatchCost,
+		o.verifiedBatchCostUSD,
+
+		o.aggregatorReward,
+		o.aggregatorRewardUSD,
+		o.aggregatorSpent,
+		o.aggregatorSpentUSD,
+
+		o.trustedSequencerTimeToEmpty,
+		o.aggregatorTimeToEmpty,
+	}
+}
+
+// The possible outcomes of a time to mine probe. A probe that times out is
+// either replaced, or timed out if it was included after the timeout.
+const (
+	TimeToMineIncluded = "included"
+	TimeToMineDropped  = "dropped"
+	TimeToMineReplaced = "replaced"
+	TimeToMineTimedOut = "timed_out"
+)
+
 type TimeToMine struct {
+	Status         string
 	Seconds        float64
+	Blocks         uint64
 	GasPrice       *big.Int
 	GasPriceFactor int64
+	DynamicFee     bool
 }
 
 type TimeToMineObserver struct {
 	timeToMine *prometheus.HistogramVec
+	blocks     *prometheus.HistogramVec
 	gasPrice   *prometheus.HistogramVec
+	probes     *prometheus.CounterVec
 }
 
 func (o *TimeToMineObserver) Notify(ctx context.Context, m Message) {
 	data := m.Data().(*TimeToMine)
 
-	o.timeToMine.WithLabelValues(m.Network().GetName(), m.Provider(), fmt.Sprint(data.GasPriceFactor)).Observe(data.Seconds)
+	txType := "legacy"
+	if data.DynamicFee {
+		txType = "dynamic_fee"
+	}
+
+	factor := fmt.Sprint(data.GasPriceFactor)
+	o.probes.WithLabelValues(m.Network().GetName(), m.Provider(), factor, txType, data.Status).Inc()
 
-	gasPrice, _ := weiToGwei(data.GasPrice).Float64()
-	o.gasPrice.WithLabelValues(m.Network().GetName(), m.Provider(), fmt.Sprint(data.GasPriceFactor)).Observe(gasPrice)
+	// Only included transactions have a meaningful inclusion time. The other
+	// outcomes are tracked by the probes counter.
+	if data.Status != TimeToMineIncluded {
+		return
+	}
+
+	o.timeToMine.WithLabelValues(m.Network().GetName(), m.Provider(), factor).Observe(data.Seconds)
+	o.blocks.WithLabelValues(m.Network().GetName(), m.Provider(), factor).Observe(float64(data.Blocks))
+
+	if data.GasPrice != nil {
+		gasPrice, _ := weiToGwei(data.GasPrice).Float64()
+		o.gasPrice.WithLabelValues(m.Network().GetName(), m.Provider(), factor).Observe(gasPrice)
+	}
 }
 
 func (o *TimeToMineObserver) Register(eb *EventBus) {
@@ -1512,17 +2553,116 @@ func (o *TimeToMineObserver) Register(eb *EventBus) {
 		newExponentialBuckets(2, 8),
 		"gas_price_factor",
 	)
+	o.blocks = metrics.NewHistogram(
+		metrics.RPC,
+		"time_to_mine_blocks",
+		"Number of blocks it takes for sent transaction to be included in a block",
+		newExponentialBuckets(2, 8),
+		"gas_price_factor",
+	)
 	o.gasPrice = metrics.NewHistogram(
 		metrics.RPC,
 		"time_to_mine_gas_price",
-		"The gas price for the time to mine transactions (in gwei)",
+		"The effective gas price for the included time to mine transactions (in gwei)",
 		newExponentialBuckets(2, 10),
 		"gas_price_factor",
 	)
+	o.probes = metrics.NewCounter(
+		metrics.RPC,
+		"time_to_mine_probes",
+		"The number of time to mine transactions by outcome (included, dropped, replaced, timed_out)",
+		"gas_price_factor",
+		"tx_type",
+		"status",
+	)
 }
 
 func (o *TimeToMineObserver) GetCollectors() []prometheus.Collector {
-	return []prometheus.Collector{o.timeToMine, o.gasPrice}
+	return []prometheus.Collector{o.timeToMine, o.blocks, o.gasPrice, o.probes}
+}
+
+// The kinds of gas price oracle suggestions.
+const (
+	GasPriceOracleGasPrice    = "gas_price"
+	GasPriceOraclePriorityFee = "priority_fee"
+)
+
+// GasPriceOracle compares a suggested gas price or priority fee with the
+// effective prices paid by transactions in the following blocks.
+type GasPriceOracle struct {
+	Type         string
+	Suggested    *big.Int
+	Median       *big.Int
+	Transactions int
+	// PaidBelow is the percentage of transactions that paid less than the
+	// suggestion.
+	PaidBelow float64
+}
+
+type GasPriceOracleObserver struct {
+	suggested     *prometheus.GaugeVec
+	median        *prometheus.GaugeVec
+	overestimate  *prometheus.GaugeVec
+	underestimate *prometheus.GaugeVec
+	paidBelow     *prometheus.GaugeVec
+}
+
+func (o *GasPriceOracleObserver) Notify(ctx context.Context, m Message) {
+	data := m.Data().(*GasPriceOracle)
+
+	suggested, _ := weiToGwei(data.Suggested).Float64()
+	median, _ := weiToGwei(data.Median).Float64()
+
+	o.suggested.WithLabelValues(m.Network().GetName(), m.Provider(), data.Type).Set(suggested)
+	o.median.WithLabelValues(m.Network().GetName(), m.Provider(), data.Type).Set(median)
+	o.paidBelow.WithLabelValues(m.Network().GetName(), m.Provider(), data.Type).Set(data.PaidBelow)
+
+	if median == 0 {
+		return
+	}
+
+	ratio := suggested / median
+	o.overestimate.WithLabelValues(m.Network().GetName(), m.Provider(), data.Type).Set(math.Max(ratio-1, 0))
+	o.underestimate.WithLabelValues(m.Network().GetName(), m.Provider(), data.Type).Set(math.Max(1-ratio, 0))
+}
+
+func (o *GasPriceOracleObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.GasPriceOracle, o)
+
+	o.suggested = metrics.NewGauge(
+		metrics.RPC,
+		"gas_price_oracle_suggested",
+		"The suggested gas price or priority fee (in gwei)",
+		"type",
+	)
+	o.median = metrics.NewGauge(
+		metrics.RPC,
+		"gas_price_oracle_median_paid",
+		"The median effective gas price or priority fee paid in the blocks after the suggestion (in gwei)",
+		"type",
+	)
+	o.overestimate = metrics.NewGauge(
+		metrics.RPC,
+		"gas_price_oracle_overestimation_ratio",
+		"How much the suggestion exceeds the median paid, relative to the median paid",
+		"type",
+	)
+	o.underestimate = metrics.NewGauge(
+		metrics.RPC,
+		"gas_price_oracle_underestimation_ratio",
+		"How much the suggestion falls short of the median paid, relative to the median paid",
+		"type",
+	)
+	o.paidBelow = metrics.NewGauge(
+		metrics.RPC,
+		"gas_price_oracle_paid_below_suggestion",
+		"The percentage of included transactions that paid less than the suggestion",
+		"type",
+	)
+}
+
+func (o *GasPriceOracleObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.suggested, o.median, o.overestimate, o.underestimate, o.paidBelow}
 }
 
 type AccountBalances map[common.Address]*TokenBalances
@@ -1566,15 +2706,126 @@ func (o *AccountBalancesObserver) GetCollectors() []prometheus.Collector {
 	return []prometheus.Collector{o.balance}
 }
 
+type ERC20Balance struct {
+	Account common.Address
+	Token   common.Address
+	Symbol  string
+	Balance *big.Float // The balance with the token decimals applied
+	USD     *float64
+
+	LowBalanceThreshold *float64
+}
+
+type ERC20Balances []*ERC20Balance
+
+type ERC20BalancesObserver struct {
+	balance    *prometheus.GaugeVec
+	usd        *prometheus.GaugeVec
+	lowBalance *prometheus.GaugeVec
+}
+
+func (o *ERC20BalancesObserver) Notify(ctx context.Context, m Message) {
+	data := m.Data().(ERC20Balances)
+
+	for _, b := range data {
+		labels := []string{m.Network().GetName(), m.Provider(), b.Account.Hex(), b.Token.Hex(), b.Symbol}
+
+		balance, _ := b.Balance.Float64()
+		o.balance.WithLabelValues(labels...).Set(balance)
+
+		if b.USD != nil {
+			o.usd.WithLabelValues(labels...).Set(*b.USD)
+		}
+
+		if b.LowBalanceThreshold != nil {
+			low := 0.0
+			if balance < *b.LowBalanceThreshold {
+				low = 1
+			}
+			o.lowBalance.WithLabelValues(labels...).Set(low)
+		}
+	}
+}
+
+func (o *ERC20BalancesObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.ERC20Balances, o)
+
+	o.balance = metrics.NewGauge(
+		metrics.RPC,
+		"account_token_balance",
+		"The account ERC20 token balance (in token units)",
+		"address",
+		"token",
+		"symbol",
+	)
+	o.usd = metrics.NewGauge(
+		metrics.RPC,
+		"account_token_balance_usd",
+		"The account ERC20 token balance (in USD)",
+		"address",
+		"token",
+		"symbol",
+	)
+	o.lowBalance = metrics.NewGauge(
+		metrics.RPC,
+		"account_token_low_balance",
+		"Whether the account ERC20 token balance is below the configured threshold",
+		"address",
+		"token",
+		"symbol",
+	)
+}
+
+func (o *ERC20BalancesObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.balance, o.usd, o.lowBalance}
+}
+
+// TrustedBatch is a closed trusted batch. The closing reason is only set when
+// the RPC returns it.
+type TrustedBatch struct {
+	*zkevmtypes.Batch
+
+	ClosingReason string
+	OpenToClose   *time.Duration
+}
+
 type TrustedBatchObserver struct {
-	length *prometheus.HistogramVec
+	length         *prometheus.HistogramVec
+	blocks         *prometheus.HistogramVec
+	openToClose    *prometheus.HistogramVec
+	closed         *prometheus.CounterVec
+	empty          *prometheus.CounterVec
+	globalExitRoot *prometheus.CounterVec
 }
 
 func (o *TrustedBatchObserver) Notify(ctx context.Context, m Message) {
-	batch := m.Data().(*zkevmtypes.Batch)
+	batch := m.Data().(*TrustedBatch)
+	labels := []string{m.Network().GetName(), m.Provider()}
 
 	length := float64(len(batch.Transactions))
-	o.length.WithLabelValues(m.Network().GetName(), m.Provider()).Observe(length)
+	o.length.WithLabelValues(labels...).Observe(length)
+
+	blocks := float64(len(batch.Blocks))
+	o.blocks.WithLabelValues(labels...).Observe(blocks)
+
+	if batch.OpenToClose != nil {
+		o.openToClose.WithLabelValues(labels...).Observe(batch.OpenToClose.Seconds())
+	}
+
+	reason := batch.ClosingReason
+	if len(reason) == 0 {
+		reason = "unknown"
+	}
+	o.closed.WithLabelValues(append(labels, reason)...).Inc()
+
+	if len(batch.Transactions) == 0 {
+		o.empty.WithLabelValues(labels...).Inc()
+	}
+
+	// Batches that don't update the global exit root have a zero hash.
+	if batch.GlobalExitRoot != (common.Hash{}) {
+		o.globalExitRoot.WithLabelValues(labels...).Inc()
+	}
 }
 
 func (o *TrustedBatchObserver) Register(eb *EventBus) {
@@ -1586,10 +2837,38 @@ func (o *TrustedBatchObserver) Register(eb *EventBus) {
 		"The number of transactions per trusted batch",
 		newExponentialBuckets(2, 8),
 	)
+	o.blocks = metrics.NewHistogram(
+		metrics.RPC,
+		"blocks_per_batch",
+		"The number of L2 blocks per trusted batch",
+		newExponentialBuckets(2, 8),
+	)
+	o.openToClose = metrics.NewHistogram(
+		metrics.RPC,
+		"batch_open_to_close_time",
+		"The time between a trusted batch being opened and its last L2 block (in seconds)",
+		newExponentialBuckets(2, 12),
+	)
+	o.closed = metrics.NewCounter(
+		metrics.RPC,
+		"closed_trusted_batches",
+		"The number of closed trusted batches by closing reason",
+		"reason",
+	)
+	o.empty = metrics.NewCounter(
+		metrics.RPC,
+		"empty_trusted_batches",
+		"The number of trusted batches closed without transactions",
+	)
+	o.globalExitRoot = metrics.NewCounter(
+		metrics.RPC,
+		"global_exit_root_trusted_batches",
+		"The number of trusted batches that updated the global exit root",
+	)
 }
 
 func (o *TrustedBatchObserver) GetCollectors() []prometheus.Collector {
-	return []prometheus.Collector{o.length}
+	return []prometheus.Collector{o.length, o.blocks, o.openToClose, o.closed, o.empty, o.globalExitRoot}
 }
 
 type TimeToFinalizedObserver struct {
@@ -1616,3 +2895,38 @@ func (o *TimeToFinalizedObserver) Register(eb *EventBus) {
 func (o *TimeToFinalizedObserver) GetCollectors() []prometheus.Collector {
 	return []prometheus.Collector{o.gauge}
 }
+
+type ContractCall struct {
+	Label   string
+	Address common.Address
+	Method  string
+	Value   *big.Float
+}
+
+type ContractCallObserver struct {
+	gauge *prometheus.GaugeVec
+}
+
+func (o *ContractCallObserver) Notify(ctx context.Context, m Message) {
+	call := m.Data().(*ContractCall)
+
+	value, _ := call.Value.Float64()
+	o.gauge.WithLabelValues(m.Network().GetName(), m.Provider(), call.Label, call.Address.Hex(), call.Method).Set(value)
+}
+
+func (o *ContractCallObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.ContractCall, o)
+
+	o.gauge = metrics.NewGauge(
+		metrics.RPC,
+		"contract_call",
+		"The return value of a configured contract call (scaled by decimals and scale)",
+		"label",
+		"address",
+		"method",
+	)
+}
+
+func (o *ContractCallObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.gauge}
+}
@@ -182,13 +182,27 @@ func (o *SensorBogonBlockObserver) Notify(ctx context.Context, m Message) {
 
 		if err != nil {
 			logger.Warn().Err(err).Msg("Failed to get block signer")
-			return
+			continue
 		}
 		signer := "0x" + hex.EncodeToString(bytes)
 
-		if _, ok := signers[signer]; !ok {
-			o.bogonBlocks.WithLabelValues(m.Network().GetName(), m.Provider(), signer).Inc()
+		if _, ok := signers[signer]; ok {
+			continue
 		}
+
+		// Unknown signers force a validator set refresh in case the signer was
+		// rotated, and are treated as bogons if it fails.
+		validator, ok, err := api.Signer(m.Network(), signer)
+		if err != nil {
+			logger.Warn().Err(err).Msg("Failed to get signer validator")
+		}
+
+		if ok {
+			signers[signer] = validator
+			continue
+		}
+
+		o.bogonBlocks.WithLabelValues(m.Network().GetName(), m.Provider(), signer).Inc()
 	}
 }
 
@@ -44,11 +44,40 @@ func _() {
 	_ = x[ExchangeRate-33]
 	_ = x[TimeToFinalized-34]
 	_ = x[FinalizedHeight-35]
+	_ = x[ContractCall-36]
+	_ = x[ERC20Balances-37]
+	_ = x[GasPriceOracle-38]
+	_ = x[ValidatorScorecards-39]
+	_ = x[CheckpointParticipation-40]
+	_ = x[HeimdallEndpoint-41]
+	_ = x[SpanProducerChange-42]
+	_ = x[BorSpan-43]
+	_ = x[ValidatorSet-44]
+	_ = x[ValidatorSetChanges-45]
+	_ = x[ValidatorCache-46]
+	_ = x[HeimdallConsensus-47]
+	_ = x[StateSyncEvents-48]
+	_ = x[StateSyncLatency-49]
+	_ = x[BridgeActivity-50]
+	_ = x[BridgeReconciliation-51]
+	_ = x[BridgeTokenFlows-52]
+	_ = x[BridgeLockedBalances-53]
+	_ = x[L1GlobalExitRoots-54]
+	_ = x[L2GlobalExitRoots-55]
+	_ = x[ExitRootConsistency-56]
+	_ = x[GlobalExitRootSync-57]
+	_ = x[TrustedBatchTimes-58]
+	_ = x[RollupBatchStages-59]
+	_ = x[BatchPipeline-60]
+	_ = x[RollupEconomics-61]
+	_ = x[OPSyncStatus-62]
+	_ = x[OPOutputProposals-63]
+	_ = x[OPBalances-64]
 }
 
-const _ObservableTopic_name = "NewEVMBlockBorStateSyncBlockIntervalCheckpointSignaturesValidatorWalletHeimdallBlockIntervalNewHeimdallBlockMilestoneReorgSensorBlocksSensorBlockEventsBorMissedBlockProposalHeimdallMissedBlockProposalCheckpointMissedCheckpointProposalMissedMilestoneProposalTransactionPoolStolenBlockHashDivergenceSystemRefreshStateTimeZkEVMBatchesExitRootsBridgeEventClaimEventDepositCountsBridgeEventTimesClaimEventTimesRollupManagerSpanTimeToMineAccountBalancesTrustedBatchExchangeRateTimeToFinalizedFinalizedHeight"
+const _ObservableTopic_name = "NewEVMBlockBorStateSyncBlockIntervalCheckpointSignaturesValidatorWalletHeimdallBlockIntervalNewHeimdallBlockMilestoneReorgSensorBlocksSensorBlockEventsBorMissedBlockProposalHeimdallMissedBlockProposalCheckpointMissedCheckpointProposalMissedMilestoneProposalTransactionPoolStolenBlockHashDivergenceSystemRefreshStateTimeZkEVMBatchesExitRootsBridgeEventClaimEventDepositCountsBridgeEventTimesClaimEventTimesRollupManagerSpanTimeToMineAccountBalancesTrustedBatchExchangeRateTimeToFinalizedFinalizedHeightContractCallERC20BalancesGasPriceOracleValidatorScorecardsCheckpointParticipationHeimdallEndpointSpanProducerChangeBorSpanValidatorSetValidatorSetChangesValidatorCacheHeimdallConsensusStateSyncEventsStateSyncLatencyBridgeActivityBridgeReconciliationBridgeTokenFlowsBridgeLockedBalancesL1GlobalExitRootsL2GlobalExitRootsExitRootConsistencyGlobalExitRootSyncTrustedBatchTimesRollupBatchStagesBatchPipelineRollupEconomicsOPSyncStatusOPOutputProposalsOPBalances"
 
-var _ObservableTopic_index = [...]uint16{0, 11, 23, 36, 56, 71, 92, 108, 117, 122, 134, 151, 173, 200, 210, 234, 257, 272, 283, 297, 303, 319, 331, 340, 351, 361, 374, 390, 405, 418, 422, 432, 447, 459, 471, 486, 501}
+var _ObservableTopic_index = [...]uint16{0, 11, 23, 36, 56, 71, 92, 108, 117, 122, 134, 151, 173, 200, 210, 234, 257, 272, 283, 297, 303, 319, 331, 340, 351, 361, 374, 390, 405, 418, 422, 432, 447, 459, 471, 486, 501, 513, 526, 540, 559, 582, 598, 616, 623, 635, 654, 668, 685, 700, 716, 730, 750, 766, 786, 803, 820, 839, 857, 874, 891, 904, 919, 931, 948, 958}
 
 func (i ObservableTopic) String() string {
 	if i < 0 || i >= ObservableTopic(len(_ObservableTopic_index)-1) {
@@ -36,8 +36,37 @@ const (
 	Span                                               // observer.HeimdallSpan
 	TimeToMine                                         // float64
 	AccountBalances                                    // observer.AccountBalances
-	TrustedBatch                                       // *zkevmtypes.Batch
+	TrustedBatch                                       // *observer.TrustedBatch
 	ExchangeRate                                       // observer.ExchangeRate
 	TimeToFinalized                                    // uint64
 	FinalizedHeight                                    // uint64
+	ContractCall                                       // *observer.ContractCall
+	ERC20Balances                                      // observer.ERC20Balances
+	GasPriceOracle                                     // *observer.GasPriceOracle
+	ValidatorScorecards                                // *observer.ValidatorScorecards
+	CheckpointParticipation                            // *observer.CheckpointParticipation
+	HeimdallEndpoint                                   // *observer.HeimdallEndpoint
+	SpanProducerChange                                 // *observer.HeimdallSpanProducerChange
+	BorSpan                                            // *observer.BorSpan
+	ValidatorSet                                       // *observer.ValidatorSet
+	ValidatorSetChanges                                // observer.ValidatorSetChanges
+	ValidatorCache                                     // api.ValidatorCacheStats
+	HeimdallConsensus                                  // *observer.HeimdallConsensus
+	StateSyncEvents                                    // *observer.StateSyncEvents
+	StateSyncLatency                                   // *observer.StateSyncLatency
+	BridgeActivity                                     // *observer.BridgeActivity
+	BridgeReconciliation                               // *observer.BridgeReconciliation
+	BridgeTokenFlows                                   // observer.BridgeTokenFlows
+	BridgeLockedBalances                               // observer.BridgeLockedBalances
+	L1GlobalExitRoots                                  // observer.GlobalExitRoots
+	L2GlobalExitRoots                                  // observer.GlobalExitRoots
+	ExitRootConsistency                                // observer.ExitRootConsistencies
+	GlobalExitRootSync                                 // *observer.GlobalExitRootSync
+	TrustedBatchTimes                                  // *observer.TrustedBatchTimes
+	RollupBatchStages                                  // observer.RollupBatchStages
+	BatchPipeline                                      // *observer.BatchPipeline
+	RollupEconomics                                    // *observer.RollupEconomics
+	OPSyncStatus                                       // *observer.OPSyncStatus
+	OPOutputProposals                                  // *observer.OPOutputProposals
+	OPBalances                                         // []observer.OPBalance
 )
@@ -0,0 +1,268 @@
+package observer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/0xPolygon/panoptichain/api"
+	"github.com/0xPolygon/panoptichain/metrics"
+	"github.com/0xPolygon/panoptichain/observer/topics"
+)
+
+// ValidatorProposals counts the proposals a validator made and missed.
+type ValidatorProposals struct {
+	Made   uint64 `json:"made"`
+	Missed uint64 `json:"missed"`
+}
+
+// ValidatorScorecard is the participation of a single validator over the
+// rolling window.
+type ValidatorScorecard struct {
+	ID     uint64 `json:"id"`
+	Signer string `json:"signer"`
+	Power  uint64 `json:"power"`
+	Jailed bool   `json:"jailed"`
+
+	// Uptime is the percentage of Heimdall blocks in the window that the
+	// validator signed.
+	Uptime       float64 `json:"uptime"`
+	SignedBlocks uint64  `json:"signed_blocks"`
+
+	// Proposals maps the proposal type (bor, heimdall, checkpoint, milestone) to
+	// the proposals made and missed.
+	Proposals map[string]ValidatorProposals `json:"proposals"`
+}
+
+// ValidatorScorecards are the scorecards of every validator in a network.
+type ValidatorScorecards struct {
+	WindowSeconds  float64               `json:"window_seconds"`
+	HeimdallBlocks uint64                `json:"heimdall_blocks"`
+	Validators     []*ValidatorScorecard `json:"validators"`
+}
+
+type ValidatorScorecardObserver struct {
+	uptime       *prometheus.GaugeVec
+	signedBlocks *prometheus.GaugeVec
+	proposals    *prometheus.GaugeVec
+	jailed       *prometheus.GaugeVec
+	power        *prometheus.GaugeVec
+}
+
+func (o *ValidatorScorecardObserver) Notify(ctx context.Context, m Message) {
+	data := m.Data().(*ValidatorScorecards)
+
+	// Remove the series of validators that are no longer in the validator set.
+	labels := prometheus.Labels{"network": m.Network().GetName(), "provider": m.Provider()}
+	for _, c := range o.GetCollectors() {
+		c.(*prometheus.GaugeVec).DeletePartialMatch(labels)
+	}
+
+	for _, v := range data.Validators {
+		id := fmt.Sprint(v.ID)
+
+		o.uptime.WithLabelValues(m.Network().GetName(), m.Provider(), id, v.Signer).Set(v.Uptime)
+		o.signedBlocks.WithLabelValues(m.Network().GetName(), m.Provider(), id, v.Signer).Set(float64(v.SignedBlocks))
+		o.power.WithLabelValues(m.Network().GetName(), m.Provider(), id, v.Signer).Set(float64(v.Power))
+
+		var jailed float64
+		if v.Jailed {
+			jailed = 1
+		}
+		o.jailed.WithLabelValues(m.Network().GetName(), m.Provider(), id, v.Signer).Set(jailed)
+
+		for kind, proposals := range v.Proposals {
+			o.proposals.WithLabelValues(m.Network().GetName(), m.Provider(), id, v.Signer, kind, "made").Set(float64(proposals.Made))
+			o.proposals.WithLabelValues(m.Network().GetName(), m.Provider(), id, v.Signer, kind, "missed").Set(float64(proposals.Missed))
+		}
+	}
+}
+
+func (o *ValidatorScorecardObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.ValidatorScorecards, o)
+
+	o.uptime = metrics.NewGauge(
+		metrics.Heimdall,
+		"validator_uptime",
+		"The percentage of Heimdall blocks signed by the validator over the rolling window",
+		"validator_id",
+		"signer_address",
+	)
+	o.signedBlocks = metrics.NewGauge(
+		metrics.Heimdall,
+		"validator_signed_blocks",
+		"The number of Heimdall blocks signed by the validator over the rolling window",
+		"validator_id",
+		"signer_address",
+	)
+	o.proposals = metrics.NewGauge(
+		metrics.Heimdall,
+		"validator_proposals",
+		"The number of proposals made and missed by the validator over the rolling window",
+		"validator_id",
+		"signer_address",
+		"type",
+		"status",
+	)
+	o.jailed = metrics.NewGauge(
+		metrics.Heimdall,
+		"validator_jailed",
+		"Whether the validator is jailed (1) or not (0)",
+		"validator_id",
+		"signer_address",
+	)
+	o.power = metrics.NewGauge(
+		metrics.Heimdall,
+		"validator_power",
+		"The voting power of the validator",
+		"validator_id",
+		"signer_address",
+	)
+}
+
+func (o *ValidatorScorecardObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.uptime, o.signedBlocks, o.proposals, o.jailed, o.power}
+}
+
+// ValidatorSet summarizes a network's validator set.
+type ValidatorSet struct {
+	Size       int
+	Active     int
+	Jailed     int
+	TotalStake uint64
+}
+
+// The types of validator set changes.
+const (
+	ValidatorJoined        = "joined"
+	ValidatorExited        = "exited"
+	ValidatorJailed        = "jailed"
+	ValidatorUnjailed      = "unjailed"
+	ValidatorPowerChanged  = "power_changed"
+	ValidatorSignerRotated = "signer_rotated"
+	ValidatorNonceChanged  = "nonce_changed"
+)
+
+// ValidatorSetChange is a single change to a validator between two validator
+// set snapshots. Previous is nil for validators that joined.
+type ValidatorSetChange struct {
+	Type      string
+	Validator api.Validator
+	Previous  api.Validator
+}
+
+type ValidatorSetChanges []ValidatorSetChange
+
+type ValidatorSetObserver struct {
+	size       *prometheus.GaugeVec
+	active     *prometheus.GaugeVec
+	jailed     *prometheus.GaugeVec
+	totalStake *prometheus.GaugeVec
+}
+
+func (o *ValidatorSetObserver) Notify(ctx context.Context, m Message) {
+	set := m.Data().(*ValidatorSet)
+
+	o.size.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(set.Size))
+	o.active.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(set.Active))
+	o.jailed.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(set.Jailed))
+	o.totalStake.WithLabelValues(m.Network().GetName(), m.Provider()).Set(float64(set.TotalStake))
+}
+
+func (o *ValidatorSetObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.ValidatorSet, o)
+
+	o.size = metrics.NewGauge(metrics.Heimdall, "validator_set_size", "The number of validators in the validator set")
+	o.active = metrics.NewGauge(metrics.Heimdall, "validator_set_active", "The number of validators that aren't jailed and have voting power")
+	o.jailed = metrics.NewGauge(metrics.Heimdall, "validator_set_jailed", "The number of jailed validators")
+	o.totalStake = metrics.NewGauge(metrics.Heimdall, "validator_set_total_stake", "The total voting power of the validator set")
+}
+
+func (o *ValidatorSetObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.size, o.active, o.jailed, o.totalStake}
+}
+
+type ValidatorSetChangeObserver struct {
+	changes *prometheus.CounterVec
+}
+
+func (o *ValidatorSetChangeObserver) Notify(ctx context.Context, m Message) {
+	logger := NewLogger(o, m)
+
+	for _, change := range m.Data().(ValidatorSetChanges) {
+		id := fmt.Sprint(change.Validator.GetID())
+
+		if change.Type == ValidatorSignerRotated {
+			logger.Warn().
+				Str("validator_id", id).
+				Str("previous_signer", change.Previous.GetSigner()).
+				Str("signer", change.Validator.GetSigner()).
+				Msg("Validator signer rotated")
+		}
+
+		o.changes.WithLabelValues(m.Network().GetName(), m.Provider(), id, change.Validator.GetSigner(), change.Type).Inc()
+	}
+}
+
+func (o *ValidatorSetChangeObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.ValidatorSetChanges, o)
+
+	o.changes = metrics.NewCounter(
+		metrics.Heimdall,
+		"validator_set_changes",
+		"The number of validator set changes such as joins, exits, jailing, power changes, and signer rotations",
+		"validator_id",
+		"signer_address",
+		"type",
+	)
+}
+
+func (o *ValidatorSetChangeObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.changes}
+}
+
+type ValidatorCacheObserver struct {
+	lookups     *prometheus.CounterVec
+	refreshes   *prometheus.CounterVec
+	rateLimited *prometheus.CounterVec
+}
+
+func (o *ValidatorCacheObserver) Notify(ctx context.Context, m Message) {
+	stats := m.Data().(api.ValidatorCacheStats)
+
+	o.lookups.WithLabelValues(m.Network().GetName(), m.Provider(), "hit").Add(float64(stats.Hits))
+	o.lookups.WithLabelValues(m.Network().GetName(), m.Provider(), "miss").Add(float64(stats.Misses))
+
+	for _, reason := range []string{api.RefreshExpired, api.RefreshForced, api.RefreshPolled} {
+		o.refreshes.WithLabelValues(m.Network().GetName(), m.Provider(), reason).Add(float64(stats.Refreshes[reason]))
+	}
+
+	o.rateLimited.WithLabelValues(m.Network().GetName(), m.Provider()).Add(float64(stats.RateLimited))
+}
+
+func (o *ValidatorCacheObserver) Register(eb *EventBus) {
+	eb.Subscribe(topics.ValidatorCache, o)
+
+	o.lookups = metrics.NewCounter(
+		metrics.Heimdall,
+		"validator_cache_lookups",
+		"The number of validator cache lookups by result (hit or miss)",
+		"result",
+	)
+	o.refreshes = metrics.NewCounter(
+		metrics.Heimdall,
+		"validator_cache_refreshes",
+		"The number of validator cache refreshes by reason (expired, forced, or polled)",
+		"reason",
+	)
+	o.rateLimited = metrics.NewCounter(
+		metrics.Heimdall,
+		"validator_cache_forced_refreshes_rate_limited",
+		"The number of forced validator cache refreshes skipped due to rate limiting",
+	)
+}
+
+func (o *ValidatorCacheObserver) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.lookups, o.refreshes, o.rateLimited}
+}
@@ -0,0 +1,393 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+
+	"github.com/0xPolygon/panoptichain/network"
+	"github.com/0xPolygon/panoptichain/observer"
+	"github.com/0xPolygon/panoptichain/observer/topics"
+)
+
+// batchPipelineRetention is how long the trusted batch times and the sequenced
+// batches that haven't been verified are kept.
+const batchPipelineRetention = 7 * 24 * time.Hour
+
+// trustedBatchChain is the trusted batch state of a single L2 network.
+type trustedBatchChain struct {
+	last  uint64
+	times map[uint64]time.Time
+}
+
+// sequencedBatches is a range of batches sequenced on L1 that haven't been
+// verified yet.
+type sequencedBatches struct {
+	first uint64
+	last  uint64
+	time  time.Time
+}
+
+// rollupBatchPipeline is the batch state of a single rollup of a rollup
+// manager.
+type rollupBatchPipeline struct {
+	network  network.Network
+	rollupID uint32
+	chainID  *uint64
+
+	// lastSequenced and lastVerified are nil until the first sequence or verify
+	// event, or until the rollup manager's rollup data is known.
+	lastSequenced *uint64
+	lastVerified  *uint64
+
+	sequenced         []sequencedBatches
+	trustedToVirtual  []time.Duration
+	virtualToVerified []time.Duration
+}
+
+// BatchPipelineProvider is a meta-provider that tracks each zkEVM batch through
+// the trusted, virtual, and verified stages. The trusted batch times published
+// by the L2 RPC providers are matched with the rollup manager's sequence and
+// verify events published by the L1 RPC providers using the rollup's chain ID.
+//
+// See ../runner/runner.go to see how this provider is initialized.
+type BatchPipelineProvider struct {
+	bus              *observer.EventBus
+	interval         uint
+	label            string
+	logger           zerolog.Logger
+	refreshStateTime *time.Duration
+
+	mu       sync.Mutex
+	chains   map[uint64]*trustedBatchChain
+	rollups  map[string]*rollupBatchPipeline
+	messages []*observer.CoreMessage
+
+	// removed are the rollups whose series are deleted on the next publish.
+	removed []*rollupBatchPipeline
+}
+
+// batchStageSubscriber forwards the trusted batch times or the rollup batch
+// stages to the batch pipeline provider.
+type batchStageSubscriber struct {
+	provider *BatchPipelineProvider
+	trusted  bool
+}
+
+func (s *batchStageSubscriber) Notify(ctx context.Context, m observer.Message) {
+	if s.trusted {
+		s.provider.notifyTrusted(m)
+	} else {
+		s.provider.notifyStages(m)
+	}
+}
+
+func (s *batchStageSubscriber) Register(eb *observer.EventBus) {
+	if s.trusted {
+		eb.Subscribe(topics.TrustedBatchTimes, s)
+	} else {
+		eb.Subscribe(topics.RollupBatchStages, s)
+	}
+}
+
+func (s *batchStageSubscriber) GetCollectors() []prometheus.Collector {
+	return nil
+}
+
+func NewBatchPipelineProvider(eb *observer.EventBus, interval uint) *BatchPipelineProvider {
+	label := "batch-pipeline"
+
+	p := &BatchPipelineProvider{
+		bus:              eb,
+		interval:         interval,
+		label:            label,
+		logger:           NewLogger(nil, label),
+		refreshStateTime: new(time.Duration),
+		chains:           make(map[uint64]*trustedBatchChain),
+		rollups:          make(map[string]*rollupBatchPipeline),
+	}
+
+	trusted := &batchStageSubscriber{provider: p, trusted: true}
+	trusted.Register(eb)
+
+	stages := &batchStageSubscriber{provider: p}
+	stages.Register(eb)
+
+	return p
+}
+
+// notifyTrusted stores the times the trusted batches were first seen. Multiple
+// providers can monitor the same L2 network, so only the earliest time is kept.
+func (p *BatchPipelineProvider) notifyTrusted(m observer.Message) {
+	data := m.Data().(*observer.TrustedBatchTimes)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	chain, ok := p.chains[data.ChainID]
+	if !ok {
+		chain = &trustedBatchChain{times: make(map[uint64]time.Time)}
+		p.chains[data.ChainID] = chain
+	}
+
+	chain.last = max(chain.last, data.Last)
+
+	for batch, t := range data.Times {
+		if prev, ok := chain.times[batch]; ok && prev.Before(t) {
+			continue
+		}
+
+		chain.times[batch] = t
+	}
+}
+
+// notifyStages stores the sequenced batch ranges and verifies them. Multiple
+// providers can monitor the same rollup manager, so events for batches that
+// were already sequenced or verified are ignored.
+func (p *BatchPipelineProvider) notifyStages(m observer.Message) {
+	stages := m.Data().(observer.RollupBatchStages)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for id, stage := range stages {
+		key := fmt.Sprintf("%s:%d", m.Network().GetName(), id)
+
+		r, ok := p.rollups[key]
+		if !ok {
+			r = &rollupBatchPipeline{network: m.Network(), rollupID: id}
+			p.rollups[key] = r
+		}
+
+		if stage.ChainID != nil {
+			r.chainID = stage.ChainID
+		}
+
+		// The trusted batches are matched before verifying, which removes the
+		// sequenced batch ranges.
+		p.sequence(r, stage.Sequenced)
+		p.match(r)
+		p.verify(r, stage.Verified)
+
+		if r.lastSequenced == nil && stage.LastBatchSequenced != nil {
+			r.lastSequenced = stage.LastBatchSequenced
+		}
+
+		if r.lastVerified == nil && stage.LastVerifiedBatch != nil {
+			r.lastVerified = stage.LastVerifiedBatch
+		}
+	}
+}
+
+// sequence adds the sequenced batch ranges. The first batch of the first
+// sequence event isn't known, so only its last batch is tracked.
+func (p *BatchPipelineProvider) sequence(r *rollupBatchPipeline, events []observer.BatchStageEvent) {
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Batch < events[j].Batch
+	})
+
+	for _, event := range events {
+		first := event.Batch
+		if r.lastSequenced != nil {
+			if event.Batch <= *r.lastSequenced {
+				continue
+			}
+
+			first = *r.lastSequenced + 1
+		}
+
+		last := event.Batch
+		r.sequenced = append(r.sequenced, sequencedBatches{first: first, last: last, time: event.Time})
+		r.lastSequenced = &last
+	}
+}
+
+// verify observes the virtual to verified latency of every sequenced batch up
+// to the verified batch.
+func (p *BatchPipelineProvider) verify(r *rollupBatchPipeline, events []observer.BatchStageEvent) {
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Batch < events[j].Batch
+	})
+
+	for _, event := range events {
+		if r.lastVerified != nil && event.Batch <= *r.lastVerified {
+			continue
+		}
+
+		sequenced := r.sequenced[:0]
+		for _, s := range r.sequenced {
+			if s.first > event.Batch {
+				sequenced = append(sequenced, s)
+				continue
+			}
+
+			if lag := event.Time.Sub(s.time); lag >= 0 {
+				for batch := s.first; batch <= min(s.last, event.Batch); batch++ {
+					r.virtualToVerified = append(r.virtualToVerified, lag)
+				}
+			}
+
+			if s.last > event.Batch {
+				s.first = event.Batch + 1
+				sequenced = append(sequenced, s)
+			}
+		}
+
+		verified := event.Batch
+		r.sequenced = sequenced
+		r.lastVerified = &verified
+	}
+}
+
+func (p *BatchPipelineProvider) RefreshState(ctx context.Context) error {
+	defer timer(p.refreshStateTime)()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = nil
+
+	for _, r := range p.rollups {
+		p.match(r)
+
+		pipeline := &observer.BatchPipeline{
+			RollupID:          r.rollupID,
+			TrustedToVirtual:  r.trustedToVirtual,
+			VirtualToVerified: r.virtualToVerified,
+		}
+		r.trustedToVirtual = nil
+		r.virtualToVerified = nil
+
+		var chain *trustedBatchChain
+		if r.chainID != nil {
+			chain = p.chains[*r.chainID]
+		}
+
+		if chain != nil && r.lastSequenced != nil {
+			var pending uint64
+			if chain.last > *r.lastSequenced {
+				pending = chain.last - *r.lastSequenced
+			}
+			pipeline.PendingVirtualization = &pending
+		}
+
+		if r.lastSequenced != nil && r.lastVerified != nil {
+			var pending uint64
+			if *r.lastSequenced > *r.lastVerified {
+				pending = *r.lastSequenced - *r.lastVerified
+			}
+			pipeline.PendingVerification = &pending
+		}
+
+		sequenced := r.sequenced[:0]
+		for _, s := range r.sequenced {
+			if time.Since(s.time) <= batchPipelineRetention {
+				sequenced = append(sequenced, s)
+			}
+		}
+		r.sequenced = sequenced
+
+		p.messages = append(p.messages, observer.NewMessage(r.network, p.label, pipeline))
+	}
+
+	for _, chain := range p.chains {
+		for batch, t := range chain.times {
+			if time.Since(t) > batchPipelineRetention {
+				delete(chain.times, batch)
+			}
+		}
+	}
+
+	return nil
+}
+
+// match observes the trusted to virtual latency of the trusted batches that
+// have been sequenced, and removes them. Trusted batches that were sequenced
+// before they were seen have a negative latency and are dropped.
+func (p *BatchPipelineProvider) match(r *rollupBatchPipeline) {
+	if r.chainID == nil || r.lastSequenced == nil {
+		return
+	}
+
+	chain, ok := p.chains[*r.chainID]
+	if !ok {
+		return
+	}
+
+	for batch, t := range chain.times {
+		if batch > *r.lastSequenced {
+			continue
+		}
+		delete(chain.times, batch)
+
+		for _, s := range r.sequenced {
+			if batch < s.first || batch > s.last {
+				continue
+			}
+
+			if lag := s.time.Sub(t); lag >= 0 {
+				r.trustedToVirtual = append(r.trustedToVirtual, lag)
+			}
+			break
+		}
+	}
+}
+
+// RemoveNetwork stops tracking the batches of the L2 network's rollup, which is
+// matched by chain ID. The rollup's series are deleted on the next publish.
+func (p *BatchPipelineProvider) RemoveNetwork(n network.Network) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	chainID := n.GetChainID()
+	delete(p.chains, chainID)
+
+	removed := make(map[uint32]network.Network)
+	for key, r := range p.rollups {
+		if r.chainID == nil || *r.chainID != chainID {
+			continue
+		}
+
+		delete(p.rollups, key)
+		p.removed = append(p.removed, r)
+		removed[r.rollupID] = r.network
+	}
+
+	p.messages = slices.DeleteFunc(slices.Clone(p.messages), func(m *observer.CoreMessage) bool {
+		n, ok := removed[m.Data().(*observer.BatchPipeline).RollupID]
+		return ok && n == m.Network()
+	})
+}
+
+func (p *BatchPipelineProvider) PublishEvents(ctx context.Context) error {
+	p.mu.Lock()
+	messages, removed := p.messages, p.removed
+	p.removed = nil
+	p.mu.Unlock()
+
+	for _, r := range removed {
+		p.bus.DeleteMatchingSeries(r.network, p.label, prometheus.Labels{"rollup": fmt.Sprint(r.rollupID)})
+	}
+
+	for _, m := range messages {
+		p.bus.Publish(ctx, topics.BatchPipeline, m)
+	}
+
+	p.bus.Publish(ctx, topics.RefreshStateTime, observer.NewMessage(nil, p.label, p.refreshStateTime))
+
+	return nil
+}
+
+func (p *BatchPipelineProvider) SetEventBus(bus *observer.EventBus) {
+	p.bus = bus
+}
+
+func (p *BatchPipelineProvider) PollingInterval() uint {
+	return p.interval
+}
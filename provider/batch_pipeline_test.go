@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/0xPolygon/panoptichain/config"
+	"github.com/0xPolygon/panoptichain/network"
+	"github.com/0xPolygon/panoptichain/observer"
+	"github.com/0xPolygon/panoptichain/observer/topics"
+)
+
+// batchPipelineSeries records the published batch pipelines as series like the
+// BatchPipelineObserver, in its own registry.
+type batchPipelineSeries struct {
+	registry *prometheus.Registry
+	pending  *prometheus.GaugeVec
+}
+
+func newBatchPipelineSeries(eb *observer.EventBus) *batchPipelineSeries {
+	o := &batchPipelineSeries{
+		registry: prometheus.NewRegistry(),
+		pending:  prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "pending"}, []string{"network", "provider", "rollup"}),
+	}
+	o.registry.MustRegister(o.pending)
+	o.Register(eb)
+
+	return o
+}
+
+func (o *batchPipelineSeries) Notify(ctx context.Context, m observer.Message) {
+	data := m.Data().(*observer.BatchPipeline)
+	o.pending.WithLabelValues(m.Network().GetName(), m.Provider(), fmt.Sprint(data.RollupID)).Set(1)
+}
+
+func (o *batchPipelineSeries) Register(eb *observer.EventBus) {
+	eb.Subscribe(topics.BatchPipeline, o)
+}
+
+func (o *batchPipelineSeries) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{o.pending}
+}
+
+// rollups returns the rollup label of every series.
+func (o *batchPipelineSeries) rollups(t *testing.T) map[string]bool {
+	families, err := o.registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather series: %v", err)
+	}
+
+	rollups := make(map[string]bool)
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "rollup" {
+					rollups[label.GetValue()] = true
+				}
+			}
+		}
+	}
+
+	return rollups
+}
+
+func TestBatchPipelineRemoveNetwork(t *testing.T) {
+	eb := observer.NewEventBus()
+	series := newBatchPipelineSeries(eb)
+	p := NewBatchPipelineProvider(eb, 1)
+
+	removed := &config.Network{Name: "Rollup 1", ChainID: 1001}
+	kept := &config.Network{Name: "Rollup 2", ChainID: 1002}
+
+	var sequenced, verified uint64 = 10, 5
+	stage := func(chainID uint64) *observer.RollupBatchStage {
+		return &observer.RollupBatchStage{ChainID: &chainID, LastBatchSequenced: &sequenced, LastVerifiedBatch: &verified}
+	}
+
+	// publish publishes the batch pipelines and waits for their notifications.
+	publish := func() {
+		if err := p.PublishEvents(context.Background()); err != nil {
+			t.Fatalf("failed to publish events: %v", err)
+		}
+
+		for eb.Jobs() > 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	p.notifyStages(observer.NewMessage(&network.Sepolia, "sepolia", observer.RollupBatchStages{
+		1: stage(removed.ChainID),
+		2: stage(kept.ChainID),
+	}))
+	p.notifyTrusted(observer.NewMessage(removed, "rollup-1", &observer.TrustedBatchTimes{ChainID: removed.ChainID, Last: 12}))
+
+	if err := p.RefreshState(context.Background()); err != nil {
+		t.Fatalf("failed to refresh state: %v", err)
+	}
+	publish()
+
+	if got, want := series.rollups(t), map[string]bool{"1": true, "2": true}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got rollup series %v, want %v", got, want)
+	}
+
+	// The batch pipelines refreshed before the removal aren't published.
+	if err := p.RefreshState(context.Background()); err != nil {
+		t.Fatalf("failed to refresh state: %v", err)
+	}
+	p.RemoveNetwork(removed)
+	publish()
+
+	if got, want := series.rollups(t), map[string]bool{"2": true}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got rollup series %v, want %v", got, want)
+	}
+
+	if _, ok := p.chains[removed.ChainID]; ok || len(p.rollups) != 1 {
+		t.Errorf("got %d rollups and removed chain %v, want the kept rollup only", len(p.rollups), ok)
+	}
+}
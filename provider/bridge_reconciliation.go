@@ -0,0 +1,380 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+
+	"github.com/0xPolygon/panoptichain/network"
+	"github.com/0xPolygon/panoptichain/observer"
+	"github.com/0xPolygon/panoptichain/observer/topics"
+)
+
+// bridgeRetention is how long unclaimed deposits and claimed deposit counts are
+// kept.
+const bridgeRetention = 7 * 24 * time.Hour
+
+// bridgeOrphanGracePeriod is how long a claim waits for its deposit to be
+// observed before it's considered an orphan.
+const bridgeOrphanGracePeriod = time.Hour
+
+var (
+	globalIndexMainnetFlag = new(big.Int).Lsh(big.NewInt(1), 64)
+	uint32Mask             = big.NewInt(0xffffffff)
+)
+
+// decodeGlobalIndex returns the source network and deposit count of a claim's
+// global index. The global index is 191 zero bits, a mainnet flag bit, 32 bits
+// of rollup index, and 32 bits of deposit count. Deposits from mainnet are from
+// network 0, and deposits from a rollup are from the rollup index + 1.
+func decodeGlobalIndex(globalIndex *big.Int) (source uint32, depositCount uint32) {
+	depositCount = uint32(new(big.Int).And(globalIndex, uint32Mask).Uint64())
+
+	if new(big.Int).And(globalIndex, globalIndexMainnetFlag).Sign() != 0 {
+		return 0, depositCount
+	}
+
+	rollupIndex := new(big.Int).Rsh(globalIndex, 32)
+	rollupIndex.And(rollupIndex, uint32Mask)
+
+	return uint32(rollupIndex.Uint64()) + 1, depositCount
+}
+
+// bridgeClaim is a claim whose deposit hasn't been observed yet.
+type bridgeClaim struct {
+	destination uint32
+	time        time.Time
+	seenAt      time.Time
+}
+
+// bridgeNetwork identifies a bridge network by the bridge contract of its
+// ecosystem and its network ID. Network IDs are only unique within an
+// ecosystem, so the same ID can refer to unrelated networks.
+type bridgeNetwork struct {
+	bridge common.Address
+	id     uint32
+}
+
+// networkBridge is the reconciliation state of the deposits made on a single
+// bridge network.
+type networkBridge struct {
+	// network is set once a provider for this bridge network publishes its
+	// activity, since claims can reference networks that aren't monitored.
+	network network.Network
+
+	firstDeposit *uint32
+	depositCount *uint32
+
+	// deposits maps the deposit count to the deposits that haven't been
+	// claimed yet.
+	deposits map[uint32]observer.BridgeDeposit
+
+	// claimed maps the deposit count of the claimed deposits to the time they
+	// were claimed, so duplicate events are ignored.
+	claimed map[uint32]time.Time
+
+	// claims maps the deposit count to the claims whose deposit hasn't been
+	// observed yet.
+	claims map[uint32]bridgeClaim
+
+	latencies map[uint32][]time.Duration
+	orphans   map[uint32]uint64
+}
+
+// BridgeReconciliationProvider is a meta-provider that matches the bridge
+// deposits made on each network with their claims on the destination network.
+// Like the StateSyncProvider, it subscribes to the bridge activity the RPC
+// providers publish rather than querying them.
+//
+// See ../runner/runner.go to see how this provider is initialized.
+type BridgeReconciliationProvider struct {
+	bus              *observer.EventBus
+	interval         uint
+	label            string
+	logger           zerolog.Logger
+	thresholds       []time.Duration
+	refreshStateTime *time.Duration
+
+	mu       sync.Mutex
+	networks map[bridgeNetwork]*networkBridge
+	messages []*observer.CoreMessage
+
+	// removed are the networks whose series are deleted on the next publish.
+	removed []network.Network
+}
+
+// bridgeActivitySubscriber forwards the bridge activity to the bridge
+// reconciliation provider.
+type bridgeActivitySubscriber struct {
+	provider *BridgeReconciliationProvider
+}
+
+func (s *bridgeActivitySubscriber) Notify(ctx context.Context, m observer.Message) {
+	s.provider.notify(m)
+}
+
+func (s *bridgeActivitySubscriber) Register(eb *observer.EventBus) {
+	eb.Subscribe(topics.BridgeActivity, s)
+}
+
+func (s *bridgeActivitySubscriber) GetCollectors() []prometheus.Collector {
+	return nil
+}
+
+func NewBridgeReconciliationProvider(eb *observer.EventBus, interval uint, thresholds []time.Duration) *BridgeReconciliationProvider {
+	label := "bridge-reconciliation"
+
+	p := &BridgeReconciliationProvider{
+		bus:              eb,
+		interval:         interval,
+		label:            label,
+		logger:           NewLogger(nil, label),
+		thresholds:       thresholds,
+		refreshStateTime: new(time.Duration),
+		networks:         make(map[bridgeNetwork]*networkBridge),
+	}
+
+	s := &bridgeActivitySubscriber{provider: p}
+	s.Register(eb)
+
+	return p
+}
+
+func (p *BridgeReconciliationProvider) getNetwork(id bridgeNetwork) *networkBridge {
+	nb, ok := p.networks[id]
+	if !ok {
+		nb = &networkBridge{
+			deposits:  make(map[uint32]observer.BridgeDeposit),
+			claimed:   make(map[uint32]time.Time),
+			claims:    make(map[uint32]bridgeClaim),
+			latencies: make(map[uint32][]time.Duration),
+			orphans:   make(map[uint32]uint64),
+		}
+		p.networks[id] = nb
+	}
+
+	return nb
+}
+
+// notify matches the deposits and claims by source network and deposit count
+// within the bridge's ecosystem. Multiple providers can publish the same events,
+// so deposits that were already claimed are ignored.
+func (p *BridgeReconciliationProvider) notify(m observer.Message) {
+	activity := m.Data().(*observer.BridgeActivity)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	nb := p.getNetwork(bridgeNetwork{bridge: activity.Bridge, id: activity.NetworkID})
+	nb.network = m.Network()
+
+	if dc := activity.DepositCount; dc != nil && (nb.depositCount == nil || *dc > *nb.depositCount) {
+		nb.depositCount = dc
+	}
+
+	for _, deposit := range activity.Deposits {
+		dc := deposit.DepositCount
+		if nb.firstDeposit == nil || dc < *nb.firstDeposit {
+			nb.firstDeposit = &dc
+		}
+
+		if _, ok := nb.claimed[dc]; ok {
+			continue
+		}
+
+		if claim, ok := nb.claims[dc]; ok {
+			nb.addLatency(claim.destination, claim.time.Sub(deposit.Time))
+			nb.claimed[dc] = claim.time
+			delete(nb.claims, dc)
+			continue
+		}
+
+		nb.deposits[dc] = deposit
+	}
+
+	for _, claim := range activity.Claims {
+		if claim.GlobalIndex == nil {
+			continue
+		}
+
+		source, dc := decodeGlobalIndex(claim.GlobalIndex)
+		src := p.getNetwork(bridgeNetwork{bridge: activity.Bridge, id: source})
+
+		if _, ok := src.claimed[dc]; ok {
+			continue
+		}
+
+		if deposit, ok := src.deposits[dc]; ok {
+			src.addLatency(activity.NetworkID, claim.Time.Sub(deposit.Time))
+			src.claimed[dc] = claim.Time
+			delete(src.deposits, dc)
+			continue
+		}
+
+		if _, ok := src.claims[dc]; !ok {
+			src.claims[dc] = bridgeClaim{
+				destination: activity.NetworkID,
+				time:        claim.Time,
+				seenAt:      time.Now(),
+			}
+		}
+	}
+}
+
+func (nb *networkBridge) addLatency(destination uint32, latency time.Duration) {
+	if latency < 0 {
+		return
+	}
+
+	nb.latencies[destination] = append(nb.latencies[destination], latency)
+}
+
+func (p *BridgeReconciliationProvider) RefreshState(ctx context.Context) error {
+	defer timer(p.refreshStateTime)()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = nil
+
+	for id, nb := range p.networks {
+		// Claims whose deposit wasn't observed within the grace period are
+		// orphans if their deposit count is in the observed range, otherwise
+		// the deposit was made before the providers started.
+		for dc, claim := range nb.claims {
+			if time.Since(claim.seenAt) < bridgeOrphanGracePeriod {
+				continue
+			}
+
+			if nb.firstDeposit != nil && nb.depositCount != nil && dc >= *nb.firstDeposit && dc < *nb.depositCount {
+				nb.orphans[claim.destination]++
+				p.logger.Warn().
+					Any("bridge", id.bridge).
+					Uint32("source_network", id.id).
+					Uint32("deposit_count", dc).
+					Uint32("destination_network", claim.destination).
+					Msg("Claim has no matching bridge deposit")
+			}
+
+			delete(nb.claims, dc)
+		}
+
+		for dc, deposit := range nb.deposits {
+			if time.Since(deposit.Time) > bridgeRetention {
+				delete(nb.deposits, dc)
+			}
+		}
+
+		for dc, claimed := range nb.claimed {
+			if time.Since(claimed) > bridgeRetention {
+				delete(nb.claimed, dc)
+			}
+		}
+
+		// Claims can reference bridge networks that aren't monitored.
+		if nb.network == nil {
+			continue
+		}
+
+		reconciliation := &observer.BridgeReconciliation{
+			Latencies:    nb.latencies,
+			Unclaimed:    nb.unclaimed(p.thresholds),
+			OrphanClaims: nb.orphans,
+		}
+		nb.latencies = make(map[uint32][]time.Duration)
+		nb.orphans = make(map[uint32]uint64)
+
+		p.messages = append(p.messages, observer.NewMessage(nb.network, p.label, reconciliation))
+	}
+
+	return nil
+}
+
+// unclaimed returns the unclaimed deposits older than each threshold grouped by
+// destination network.
+func (nb *networkBridge) unclaimed(thresholds []time.Duration) []observer.BridgeUnclaimed {
+	var destinations []uint32
+	seen := make(map[uint32]struct{})
+	for _, deposit := range nb.deposits {
+		if _, ok := seen[deposit.DestinationNetwork]; !ok {
+			seen[deposit.DestinationNetwork] = struct{}{}
+			destinations = append(destinations, deposit.DestinationNetwork)
+		}
+	}
+	sort.Slice(destinations, func(i, j int) bool { return destinations[i] < destinations[j] })
+
+	var unclaimed []observer.BridgeUnclaimed
+	for _, destination := range destinations {
+		for _, threshold := range thresholds {
+			u := observer.BridgeUnclaimed{
+				DestinationNetwork: destination,
+				Threshold:          threshold,
+				Ether:              new(big.Int),
+			}
+
+			for _, deposit := range nb.deposits {
+				if deposit.DestinationNetwork != destination || time.Since(deposit.Time) < threshold {
+					continue
+				}
+
+				u.Count++
+				if deposit.IsEther() && deposit.Amount != nil {
+					u.Ether.Add(u.Ether, deposit.Amount)
+				}
+			}
+
+			unclaimed = append(unclaimed, u)
+		}
+	}
+
+	return unclaimed
+}
+
+// RemoveNetwork stops reconciling the deposits made on the network. Its series
+// are deleted on the next publish.
+func (p *BridgeReconciliationProvider) RemoveNetwork(n network.Network) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for id, nb := range p.networks {
+		if nb.network != nil && nb.network.GetName() == n.GetName() {
+			delete(p.networks, id)
+		}
+	}
+
+	p.messages = withoutNetwork(p.messages, n)
+	p.removed = append(p.removed, n)
+}
+
+func (p *BridgeReconciliationProvider) PublishEvents(ctx context.Context) error {
+	p.mu.Lock()
+	messages, removed := p.messages, p.removed
+	p.removed = nil
+	p.mu.Unlock()
+
+	for _, n := range removed {
+		p.bus.DeleteSeries(n, p.label)
+	}
+
+	for _, m := range messages {
+		p.bus.Publish(ctx, topics.BridgeReconciliation, m)
+	}
+
+	p.bus.Publish(ctx, topics.RefreshStateTime, observer.NewMessage(nil, p.label, p.refreshStateTime))
+
+	return nil
+}
+
+func (p *BridgeReconciliationProvider) SetEventBus(bus *observer.EventBus) {
+	p.bus = bus
+}
+
+func (p *BridgeReconciliationProvider) PollingInterval() uint {
+	return p.interval
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/0xPolygon/panoptichain/network"
+	"github.com/0xPolygon/panoptichain/observer"
+)
+
+// TestBridgeReconciliationEcosystems checks that deposits are only matched with
+// claims from the same ecosystem when two ecosystems share network IDs.
+func TestBridgeReconciliationEcosystems(t *testing.T) {
+	p := NewBridgeReconciliationProvider(observer.NewEventBus(), 1, []time.Duration{time.Minute})
+
+	mainnet, testnet := common.Address{1}, common.Address{2}
+	now := time.Now()
+	ether := big.NewInt(1e18)
+
+	// The same deposit count is used on both ecosystems' L1.
+	p.notify(observer.NewMessage(&network.Ethereum, "ethereum", &observer.BridgeActivity{
+		Bridge:    mainnet,
+		NetworkID: 0,
+		Deposits: []observer.BridgeDeposit{
+			{DestinationNetwork: 1, DepositCount: 5, Amount: ether, Time: now.Add(-10 * time.Minute)},
+		},
+	}))
+	p.notify(observer.NewMessage(&network.Sepolia, "sepolia", &observer.BridgeActivity{
+		Bridge:    testnet,
+		NetworkID: 0,
+		Deposits: []observer.BridgeDeposit{
+			{DestinationNetwork: 1, DepositCount: 5, Amount: ether, Time: now.Add(-20 * time.Minute)},
+		},
+	}))
+
+	// Only the testnet deposit is claimed on the testnet's network 1.
+	globalIndex := new(big.Int).Or(globalIndexMainnetFlag, big.NewInt(5))
+	p.notify(observer.NewMessage(&network.ZkEVMCardona, "cardona", &observer.BridgeActivity{
+		Bridge:    testnet,
+		NetworkID: 1,
+		Claims: []observer.BridgeClaim{
+			{GlobalIndex: globalIndex, Amount: ether, Time: now.Add(-5 * time.Minute)},
+		},
+	}))
+
+	if err := p.RefreshState(context.Background()); err != nil {
+		t.Fatalf("failed to refresh state: %v", err)
+	}
+
+	reconciliations := make(map[string]*observer.BridgeReconciliation)
+	for _, m := range p.messages {
+		reconciliations[m.Network().GetName()] = m.Data().(*observer.BridgeReconciliation)
+	}
+
+	if len(reconciliations) != 3 {
+		t.Fatalf("got %d reconciliations, want 3", len(reconciliations))
+	}
+
+	got := reconciliations[network.SepoliaName]
+	if want := map[uint32][]time.Duration{1: {15 * time.Minute}}; !reflect.DeepEqual(got.Latencies, want) {
+		t.Errorf("got testnet latencies %v, want %v", got.Latencies, want)
+	}
+	if len(got.Unclaimed) != 0 {
+		t.Errorf("got testnet unclaimed deposits %+v, want none", got.Unclaimed)
+	}
+
+	got = reconciliations[network.EthereumName]
+	if len(got.Latencies) != 0 {
+		t.Errorf("got mainnet latencies %v, want none", got.Latencies)
+	}
+	if len(got.Unclaimed) != 1 || got.Unclaimed[0].Count != 1 || got.Unclaimed[0].Ether.Cmp(ether) != 0 {
+		t.Errorf("got mainnet unclaimed deposits %+v, want one ether deposit", got.Unclaimed)
+	}
+}
@@ -0,0 +1,407 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/0xPolygon/panoptichain/config"
+	"github.com/0xPolygon/panoptichain/contracts"
+	"github.com/0xPolygon/panoptichain/observer"
+)
+
+// multicall3Address is the address Multicall3 is deployed to on most EVM
+// chains. See https://github.com/mds1/multicall.
+var multicall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+// contractCall is a parsed config.ContractCall that is ready to be executed.
+type contractCall struct {
+	config.ContractCall
+	address common.Address
+	method  abi.Method
+	data    []byte
+}
+
+// newContractCall parses the function signature and packs the arguments of the
+// configured contract call.
+func newContractCall(cc config.ContractCall) (*contractCall, error) {
+	if !common.IsHexAddress(cc.Address) {
+		return nil, fmt.Errorf("invalid contract address %s", cc.Address)
+	}
+
+	method, err := parseSignature(cc.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cc.Args) != len(method.Inputs) {
+		return nil, fmt.Errorf("expected %d args but got %d", len(method.Inputs), len(cc.Args))
+	}
+
+	if int(cc.Output) >= len(method.Outputs) {
+		return nil, fmt.Errorf("output index %d out of range", cc.Output)
+	}
+
+	args := make([]any, len(cc.Args))
+	for i, arg := range cc.Args {
+		args[i], err = parseArgument(method.Inputs[i].Type, arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse arg %d: %w", i, err)
+		}
+	}
+
+	inputs, err := method.Inputs.Pack(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contractCall{
+		ContractCall: cc,
+		address:      common.HexToAddress(cc.Address),
+		method:       *method,
+		data:         append(method.ID, inputs...),
+	}, nil
+}
+
+// parseSignature parses human-readable function signatures such as
+// "totalSupply()(uint256)" or "function balanceOf(address) view returns (uint256)"
+// into an ABI method. Tuple types are not supported.
+func parseSignature(signature string) (*abi.Method, error) {
+	signature = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(signature), "function "))
+
+	open := strings.Index(signature, "(")
+	if open <= 0 {
+		return nil, fmt.Errorf("invalid signature %q", signature)
+	}
+	name := strings.TrimSpace(signature[:open])
+
+	close := strings.Index(signature, ")")
+	if close < open {
+		return nil, fmt.Errorf("invalid signature %q", signature)
+	}
+
+	inputs, err := parseArguments(signature[open+1 : close])
+	if err != nil {
+		return nil, err
+	}
+
+	rest := signature[close+1:]
+	for _, keyword := range []string{"external", "public", "view", "pure", "returns"} {
+		rest = strings.ReplaceAll(rest, keyword, "")
+	}
+	rest = strings.TrimSpace(rest)
+
+	if !strings.HasPrefix(rest, "(") || !strings.HasSuffix(rest, ")") {
+		return nil, fmt.Errorf("signature %q has no return values", signature)
+	}
+
+	outputs, err := parseArguments(rest[1 : len(rest)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(outputs) == 0 {
+		return nil, fmt.Errorf("signature %q has no return values", signature)
+	}
+
+	method := abi.NewMethod(name, name, abi.Function, "view", false, false, inputs, outputs)
+	return &method, nil
+}
+
+// parseArguments parses a comma separated list of types. Parameter names are
+// allowed but ignored.
+func parseArguments(list string) (abi.Arguments, error) {
+	var args abi.Arguments
+	if len(strings.TrimSpace(list)) == 0 {
+		return args, nil
+	}
+
+	for _, field := range strings.Split(list, ",") {
+		parts := strings.Fields(field)
+		if len(parts) == 0 {
+			return nil, fmt.Errorf("empty type in %q", list)
+		}
+
+		t, err := abi.NewType(parts[0], "", nil)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, abi.Argument{Type: t})
+	}
+
+	return args, nil
+}
+
+// parseArgument converts the string representation of an argument into the Go
+// type expected by the ABI encoder.
+func parseArgument(t abi.Type, s string) (any, error) {
+	switch t.T {
+	case abi.AddressTy:
+		if !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("invalid address %s", s)
+		}
+		return common.HexToAddress(s), nil
+
+	case abi.UintTy, abi.IntTy:
+		n, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %s", s)
+		}
+
+		if !fitsInteger(t, n) {
+			return nil, fmt.Errorf("integer %s out of range for %s", s, t.String())
+		}
+
+		if t.Size > 64 {
+			return n, nil
+		}
+
+		v := reflect.New(t.GetType()).Elem()
+		if t.T == abi.UintTy {
+			v.SetUint(n.Uint64())
+		} else {
+			v.SetInt(n.Int64())
+		}
+		return v.Interface(), nil
+
+	case abi.BoolTy:
+		return strconv.ParseBool(s)
+
+	case abi.StringTy:
+		return s, nil
+
+	case abi.BytesTy:
+		return hexutil.Decode(s)
+
+	case abi.FixedBytesTy:
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return nil, err
+		}
+
+		v := reflect.New(t.GetType()).Elem()
+		reflect.Copy(v, reflect.ValueOf(common.RightPadBytes(b, t.Size)))
+		return v.Interface(), nil
+	}
+
+	return nil, fmt.Errorf("unsupported argument type %s", t.String())
+}
+
+// fitsInteger checks if n is within the range of the int or uint type t.
+func fitsInteger(t abi.Type, n *big.Int) bool {
+	if t.T == abi.UintTy {
+		return n.Sign() >= 0 && n.BitLen() <= t.Size
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+	return n.Cmp(new(big.Int).Neg(limit)) >= 0 && n.Cmp(limit) < 0
+}
+
+// toBigFloat converts an unpacked numeric return value to a big.Float.
+func toBigFloat(value any) (*big.Float, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		return new(big.Float).SetInt(v), nil
+	case bool:
+		if v {
+			return big.NewFloat(1), nil
+		}
+		return big.NewFloat(0), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Float).SetUint64(rv.Uint()), nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(rv.Int()), nil
+	}
+
+	return nil, fmt.Errorf("unsupported return type %T", value)
+}
+
+// value unpacks the return data and applies the configured decimals and scale.
+func (cc *contractCall) value(data []byte) (*big.Float, error) {
+	values, err := cc.method.Outputs.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := toBigFloat(values[cc.Output])
+	if err != nil {
+		return nil, err
+	}
+
+	if cc.Decimals > 0 {
+		divisor := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(cc.Decimals)), nil))
+		value.Quo(value, divisor)
+	}
+
+	if cc.Scale != 0 {
+		value.Mul(value, big.NewFloat(cc.Scale))
+	}
+
+	return value, nil
+}
+
+// refreshContractCalls executes the configured contract calls. The calls are
+// aggregated through Multicall3 if it is deployed, otherwise they are sent as a
+// JSON-RPC batch request.
+func (r *RPCProvider) refreshContractCalls(ctx context.Context, c *ethclient.Client) {
+	r.contractCallResults = nil
+
+	if len(r.contractCalls) == 0 {
+		return
+	}
+
+	results, err := r.multicall(ctx, c)
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("Failed to aggregate contract calls, falling back to batch request")
+
+		results, err = r.batchCall(ctx, c)
+		if err != nil {
+			r.logger.Error().Err(err).Msg("Failed to execute batch request for contract calls")
+			return
+		}
+	}
+
+	for i, cc := range r.contractCalls {
+		if results[i] == nil {
+			r.logger.Warn().
+				Str("label", cc.Label).
+				Str("address", cc.Address).
+				Str("method", cc.method.Name).
+				Msg("Contract call failed")
+			continue
+		}
+
+		value, err := cc.value(results[i])
+		if err != nil {
+			r.logger.Warn().
+				Err(err).
+				Str("label", cc.Label).
+				Str("address", cc.Address).
+				Str("method", cc.method.Name).
+				Msg("Failed to decode contract call result")
+			continue
+		}
+
+		r.contractCallResults = append(r.contractCallResults, &observer.ContractCall{
+			Label:   cc.Label,
+			Address: cc.address,
+			Method:  cc.method.Name,
+			Value:   value,
+		})
+	}
+}
+
+// getMulticallAddress returns the Multicall3 address if the contract is
+// available on the network. The result is only checked once.
+func (r *RPCProvider) getMulticallAddress(ctx context.Context, c *ethclient.Client) *common.Address {
+	if r.multicallChecked {
+		return r.multicallAddress
+	}
+
+	address := multicall3Address
+	if r.contracts.MulticallAddress != nil {
+		address = common.HexToAddress(*r.contracts.MulticallAddress)
+	}
+
+	code, err := c.CodeAt(ctx, address, nil)
+	if err != nil {
+		r.logger.Warn().Err(err).Msg("Failed to get Multicall3 code")
+		return nil
+	}
+
+	r.multicallChecked = true
+	if len(code) > 0 {
+		r.multicallAddress = &address
+	}
+
+	return r.multicallAddress
+}
+
+func (r *RPCProvider) multicall(ctx context.Context, c *ethclient.Client) ([][]byte, error) {
+	address := r.getMulticallAddress(ctx, c)
+	if address == nil {
+		return nil, errors.New("multicall is not available")
+	}
+
+	contract, err := contracts.NewMulticall3Caller(*address, c)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]contracts.Multicall3Call3, len(r.contractCalls))
+	for i, cc := range r.contractCalls {
+		calls[i] = contracts.Multicall3Call3{
+			Target:       cc.address,
+			AllowFailure: true,
+			CallData:     cc.data,
+		}
+	}
+
+	var out []any
+	raw := contracts.Multicall3CallerRaw{Contract: contract}
+	if err := raw.Call(&bind.CallOpts{Context: ctx}, &out, "aggregate3", calls); err != nil {
+		return nil, err
+	}
+
+	aggregated := *abi.ConvertType(out[0], new([]contracts.Multicall3Result)).(*[]contracts.Multicall3Result)
+	if len(aggregated) != len(calls) {
+		return nil, errors.New("unexpected number of multicall results")
+	}
+
+	results := make([][]byte, len(aggregated))
+	for i, result := range aggregated {
+		if result.Success {
+			results[i] = result.ReturnData
+		}
+	}
+
+	return results, nil
+}
+
+func (r *RPCProvider) batchCall(ctx context.Context, c *ethclient.Client) ([][]byte, error) {
+	reqs := make([]rpc.BatchElem, len(r.contractCalls))
+	for i, cc := range r.contractCalls {
+		reqs[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args: []any{
+				map[string]any{
+					"to":   cc.address,
+					"data": hexutil.Bytes(cc.data),
+				},
+				"latest",
+			},
+			Result: new(hexutil.Bytes),
+		}
+	}
+
+	if err := c.Client().BatchCallContext(ctx, reqs); err != nil {
+		return nil, err
+	}
+
+	results := make([][]byte, len(reqs))
+	for i, req := range reqs {
+		if req.Error != nil {
+			continue
+		}
+
+		results[i] = *req.Result.(*hexutil.Bytes)
+	}
+
+	return results, nil
+}
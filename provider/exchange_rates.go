@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -21,6 +22,7 @@ type ExchangeRatesProvider struct {
 	coinbaseURL string
 	tokens      map[string][]string
 	rates       []observer.ExchangeRate
+	mu          sync.RWMutex
 
 	refreshStateTime *time.Duration
 }
@@ -46,15 +48,19 @@ func NewExchangeRatesProvider(coinbaseURL string, tokens map[string][]string, eb
 func (e *ExchangeRatesProvider) RefreshState(ctx context.Context) error {
 	defer timer(e.refreshStateTime)()
 
-	e.rates = nil
+	var rates []observer.ExchangeRate
 	for base, quotes := range e.tokens {
-		e.fetchRates(base, quotes)
+		rates = append(rates, e.fetchRates(base, quotes)...)
 	}
 
+	e.mu.Lock()
+	e.rates = rates
+	e.mu.Unlock()
+
 	return nil
 }
 
-func (e *ExchangeRatesProvider) fetchRates(base string, quotes []string) {
+func (e *ExchangeRatesProvider) fetchRates(base string, quotes []string) (rates []observer.ExchangeRate) {
 	url := e.coinbaseURL + base
 	r, err := http.Get(url)
 	if err != nil {
@@ -83,12 +89,29 @@ func (e *ExchangeRatesProvider) fetchRates(base string, quotes []string) {
 			continue
 		}
 
-		e.rates = append(e.rates, observer.ExchangeRate{
+		rates = append(rates, observer.ExchangeRate{
 			Base:  strings.ToLower(base),
 			Quote: strings.ToLower(quote),
 			Rate:  value,
 		})
 	}
+
+	return rates
+}
+
+// Rate returns the latest exchange rate between the base and quote currencies.
+// This is safe to call from other providers.
+func (e *ExchangeRatesProvider) Rate(base, quote string) (float64, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for _, rate := range e.rates {
+		if rate.Base == strings.ToLower(base) && rate.Quote == strings.ToLower(quote) {
+			return rate.Rate, true
+		}
+	}
+
+	return 0, false
 }
 
 func (e *ExchangeRatesProvider) PublishEvents(ctx context.Context) error {
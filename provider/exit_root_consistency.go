@@ -0,0 +1,457 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+
+	"github.com/0xPolygon/panoptichain/contracts"
+	"github.com/0xPolygon/panoptichain/network"
+	"github.com/0xPolygon/panoptichain/observer"
+	"github.com/0xPolygon/panoptichain/observer/topics"
+)
+
+// globalExitRootRetention is how long the L1 global exit roots, and the L2
+// global exit roots without a matching L1 global exit root, are kept.
+const globalExitRootRetention = 24 * time.Hour
+
+// maxGlobalExitRootChecks is the maximum number of pending L1 global exit roots
+// checked in the GlobalExitRootL2 contract per refresh.
+const maxGlobalExitRootChecks = 16
+
+// l1GlobalExitRoot is a global exit root updated on an L1 network.
+type l1GlobalExitRoot struct {
+	network string
+	time    time.Time
+}
+
+// l2GlobalExitRootSync is the global exit root state of a single L2 network.
+type l2GlobalExitRootSync struct {
+	network network.Network
+
+	// l1Network is the name of the L1 network, which is set once a global
+	// exit root used on L2 matches one updated on L1.
+	l1Network string
+
+	// used is the L1 time of the newest global exit root used on L2. Global
+	// exit roots are cumulative, so every older L1 global exit root is
+	// considered used too.
+	used time.Time
+
+	// seen maps the global exit roots used on L2 that haven't been matched yet
+	// to the L2 batch time.
+	seen map[common.Hash]time.Time
+
+	violated map[common.Hash]struct{}
+	lags     []time.Duration
+}
+
+// ExitRootConsistencyProvider is a meta-provider that checks that every global
+// exit root updated on L1 is reflected in the L2 GlobalExitRootL2 contract, or
+// used by the L2 batches, within the SLA. Like the
+// StateSyncProvider, it subscribes to the topics the RPC providers publish to
+// rather than querying them. The L1 network of each L2 network is detected from
+// the global exit roots they have in common.
+//
+// See ../runner/runner.go to see how this provider is initialized.
+type ExitRootConsistencyProvider struct {
+	bus              *observer.EventBus
+	interval         uint
+	label            string
+	logger           zerolog.Logger
+	sla              time.Duration
+	refreshStateTime *time.Duration
+
+	mu       sync.Mutex
+	l1Roots  map[common.Hash]l1GlobalExitRoot
+	networks map[string]*l2GlobalExitRootSync
+	messages []*observer.CoreMessage
+
+	// removed are the networks whose series are deleted on the next publish.
+	removed []network.Network
+}
+
+// globalExitRootSubscriber forwards the L1 or L2 global exit roots to the exit
+// root consistency provider.
+type globalExitRootSubscriber struct {
+	provider *ExitRootConsistencyProvider
+	l2       bool
+}
+
+func (s *globalExitRootSubscriber) Notify(ctx context.Context, m observer.Message) {
+	if s.l2 {
+		s.provider.notifyL2(m)
+	} else {
+		s.provider.notifyL1(m)
+	}
+}
+
+func (s *globalExitRootSubscriber) Register(eb *observer.EventBus) {
+	if s.l2 {
+		eb.Subscribe(topics.L2GlobalExitRoots, s)
+	} else {
+		eb.Subscribe(topics.L1GlobalExitRoots, s)
+	}
+}
+
+func (s *globalExitRootSubscriber) GetCollectors() []prometheus.Collector {
+	return nil
+}
+
+func NewExitRootConsistencyProvider(eb *observer.EventBus, interval uint, sla time.Duration) *ExitRootConsistencyProvider {
+	label := "exit-root-consistency"
+
+	p := &ExitRootConsistencyProvider{
+		bus:              eb,
+		interval:         interval,
+		label:            label,
+		logger:           NewLogger(nil, label),
+		sla:              sla,
+		refreshStateTime: new(time.Duration),
+		l1Roots:          make(map[common.Hash]l1GlobalExitRoot),
+		networks:         make(map[string]*l2GlobalExitRootSync),
+	}
+
+	l1 := &globalExitRootSubscriber{provider: p}
+	l1.Register(eb)
+
+	l2 := &globalExitRootSubscriber{provider: p, l2: true}
+	l2.Register(eb)
+
+	return p
+}
+
+// notifyL1 stores the L1 global exit roots. Multiple providers can publish the
+// same global exit roots, so only the first is kept.
+func (p *ExitRootConsistencyProvider) notifyL1(m observer.Message) {
+	roots := m.Data().(observer.GlobalExitRoots)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, root := range roots {
+		if _, ok := p.l1Roots[root.Hash]; ok || root.Hash == (common.Hash{}) {
+			continue
+		}
+
+		p.l1Roots[root.Hash] = l1GlobalExitRoot{network: m.Network().GetName(), time: root.Time}
+	}
+}
+
+// notifyL2 stores the global exit roots used on L2 until they're matched,
+// keeping the earliest batch time.
+func (p *ExitRootConsistencyProvider) notifyL2(m observer.Message) {
+	roots := m.Data().(observer.GlobalExitRoots)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s, ok := p.networks[m.Network().GetName()]
+	if !ok {
+		s = &l2GlobalExitRootSync{
+			network:  m.Network(),
+			seen:     make(map[common.Hash]time.Time),
+			violated: make(map[common.Hash]struct{}),
+		}
+		p.networks[m.Network().GetName()] = s
+	}
+
+	for _, root := range roots {
+		if t, ok := s.seen[root.Hash]; ok && t.Before(root.Time) {
+			continue
+		}
+
+		s.seen[root.Hash] = root.Time
+	}
+}
+
+func (p *ExitRootConsistencyProvider) RefreshState(ctx context.Context) error {
+	defer timer(p.refreshStateTime)()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = nil
+
+	for _, s := range p.networks {
+		p.match(s)
+
+		if len(s.l1Network) == 0 {
+			continue
+		}
+
+		gers := &observer.GlobalExitRootSync{
+			L1Network: s.l1Network,
+			Lags:      s.lags,
+		}
+		s.lags = nil
+
+		for hash, root := range p.l1Roots {
+			if root.network != s.l1Network || !root.time.After(s.used) {
+				continue
+			}
+
+			age := time.Since(root.time)
+			gers.Pending++
+			gers.OldestPending = max(gers.OldestPending, age)
+
+			if _, ok := s.violated[hash]; ok || age <= p.sla {
+				continue
+			}
+
+			s.violated[hash] = struct{}{}
+			gers.Violations++
+
+			p.logger.Warn().
+				Str("network", s.network.GetName()).
+				Str("global_exit_root", hash.Hex()).
+				Dur("age", age).
+				Msg("Global exit root wasn't used on L2 within the SLA")
+		}
+
+		p.messages = append(p.messages, observer.NewMessage(s.network, p.label, gers))
+	}
+
+	for hash, root := range p.l1Roots {
+		if time.Since(root.time) <= globalExitRootRetention {
+			continue
+		}
+
+		delete(p.l1Roots, hash)
+		for _, s := range p.networks {
+			delete(s.violated, hash)
+		}
+	}
+
+	return nil
+}
+
+// match matches the global exit roots used on L2 with the L1 global exit roots
+// in the order they were used. The first match is only used as the baseline.
+func (p *ExitRootConsistencyProvider) match(s *l2GlobalExitRootSync) {
+	hashes := make([]common.Hash, 0, len(s.seen))
+	for hash := range s.seen {
+		hashes = append(hashes, hash)
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		return s.seen[hashes[i]].Before(s.seen[hashes[j]])
+	})
+
+	for _, hash := range hashes {
+		l2Time := s.seen[hash]
+
+		l1, ok := p.l1Roots[hash]
+		if !ok {
+			if time.Since(l2Time) > globalExitRootRetention {
+				delete(s.seen, hash)
+			}
+			continue
+		}
+		delete(s.seen, hash)
+
+		if len(s.l1Network) == 0 {
+			s.l1Network = l1.network
+			s.used = l1.time
+			continue
+		}
+
+		if l1.network != s.l1Network || !l1.time.After(s.used) {
+			continue
+		}
+
+		for h, root := range p.l1Roots {
+			if root.network != s.l1Network || !root.time.After(s.used) || root.time.After(l1.time) {
+				continue
+			}
+
+			if lag := l2Time.Sub(root.time); lag >= 0 {
+				s.lags = append(s.lags, lag)
+			}
+			delete(s.violated, h)
+		}
+
+		s.used = l1.time
+	}
+}
+
+// RemoveNetwork stops tracking the L2 network's global exit roots. Its series
+// are deleted on the next publish.
+func (p *ExitRootConsistencyProvider) RemoveNetwork(n network.Network) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.networks, n.GetName())
+	p.messages = withoutNetwork(p.messages, n)
+	p.removed = append(p.removed, n)
+}
+
+func (p *ExitRootConsistencyProvider) PublishEvents(ctx context.Context) error {
+	p.mu.Lock()
+	messages, removed := p.messages, p.removed
+	p.removed = nil
+	p.mu.Unlock()
+
+	for _, n := range removed {
+		p.bus.DeleteSeries(n, p.label)
+	}
+
+	for _, m := range messages {
+		p.bus.Publish(ctx, topics.GlobalExitRootSync, m)
+	}
+
+	p.bus.Publish(ctx, topics.RefreshStateTime, observer.NewMessage(nil, p.label, p.refreshStateTime))
+
+	return nil
+}
+
+func (p *ExitRootConsistencyProvider) SetEventBus(bus *observer.EventBus) {
+	p.bus = bus
+}
+
+func (p *ExitRootConsistencyProvider) PollingInterval() uint {
+	return p.interval
+}
+
+// pendingGlobalExitRoots are the L1 global exit roots that haven't been found
+// in the L2 GlobalExitRootL2 contract yet. The state is shared with the L1
+// global exit root subscriber.
+type pendingGlobalExitRoots struct {
+	mu    sync.Mutex
+	roots map[common.Hash]l1GlobalExitRoot
+
+	// l1Network is the name of the L1 network, which is set once one of its
+	// global exit roots is found. The global exit roots of other L1 networks
+	// aren't checked after that.
+	l1Network string
+
+	// found is the L1 time of the newest global exit root found on L2. Older
+	// global exit roots are used too, so they aren't checked.
+	found time.Time
+
+	// cursor is the L1 time of the last global exit root checked in the
+	// previous refresh. When there are more pending global exit roots than
+	// can be checked in a refresh, the next refresh continues with the older
+	// ones, so every pending global exit root is eventually checked.
+	cursor time.Time
+}
+
+// l1GlobalExitRootSubscriber forwards the L1 global exit roots to an L2 RPC
+// provider, so they can be checked in its GlobalExitRootL2 contract.
+type l1GlobalExitRootSubscriber struct {
+	provider *RPCProvider
+}
+
+func (s *l1GlobalExitRootSubscriber) Notify(ctx context.Context, m observer.Message) {
+	s.provider.notifyL1GlobalExitRoots(m)
+}
+
+func (s *l1GlobalExitRootSubscriber) Register(eb *observer.EventBus) {
+	eb.Subscribe(topics.L1GlobalExitRoots, s)
+}
+
+func (s *l1GlobalExitRootSubscriber) GetCollectors() []prometheus.Collector {
+	return nil
+}
+
+func (r *RPCProvider) notifyL1GlobalExitRoots(m observer.Message) {
+	roots := m.Data().(observer.GlobalExitRoots)
+	network := m.Network().GetName()
+
+	p := &r.pendingGlobalExitRoots
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.l1Network) > 0 && p.l1Network != network {
+		return
+	}
+
+	for _, root := range roots {
+		if _, ok := p.roots[root.Hash]; ok || root.Hash == (common.Hash{}) || !root.Time.After(p.found) {
+			continue
+		}
+
+		p.roots[root.Hash] = l1GlobalExitRoot{network: network, time: root.Time}
+	}
+}
+
+// checkL2GlobalExitRoots checks the pending L1 global exit roots, newest first,
+// in the GlobalExitRootL2 contract's global exit root map. Global exit roots
+// are cumulative, so once one is found the older ones are used too. The found
+// global exit root is published with the time it was found.
+//
+// Not every global exit root is injected on L2, so the presence of the roots
+// isn't ordered and they can't be binary searched. Instead, at most
+// maxGlobalExitRootChecks are checked per refresh: the newest one, then the
+// ones older than the cursor.
+func (r *RPCProvider) checkL2GlobalExitRoots(contract *contracts.PolygonZkEVMGlobalExitRootL2, co *bind.CallOpts) {
+	p := &r.pendingGlobalExitRoots
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	hashes := make([]common.Hash, 0, len(p.roots))
+	for hash, root := range p.roots {
+		if time.Since(root.time) > globalExitRootRetention {
+			delete(p.roots, hash)
+			continue
+		}
+
+		hashes = append(hashes, hash)
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		return p.roots[hashes[i]].time.After(p.roots[hashes[j]].time)
+	})
+
+	start := sort.Search(len(hashes), func(i int) bool {
+		return p.roots[hashes[i]].time.Before(p.cursor)
+	})
+
+	checks := hashes
+	if start > 0 && start < len(hashes) {
+		checks = append([]common.Hash{hashes[0]}, hashes[start:]...)
+		checks = append(checks, hashes[1:start]...)
+	}
+
+	var cursor time.Time
+	if len(checks) > maxGlobalExitRootChecks {
+		checks = checks[:maxGlobalExitRootChecks]
+		cursor = p.roots[checks[len(checks)-1]].time
+	}
+
+	for _, hash := range checks {
+		root := p.roots[hash]
+
+		value, err := contract.GlobalExitRootMap(co, hash)
+		if err != nil {
+			r.logger.Error().Err(err).Msg("Failed to get global exit root from global exit root map")
+			return
+		}
+
+		if value.Sign() == 0 {
+			continue
+		}
+
+		r.l2GlobalExitRoots = append(r.l2GlobalExitRoots, observer.ExitRoot{
+			Hash: hash,
+			Time: time.Now(),
+		})
+
+		p.l1Network = root.network
+		p.found = root.time
+		p.cursor = time.Time{}
+		for h, older := range p.roots {
+			if older.network != root.network || !older.time.After(root.time) {
+				delete(p.roots, h)
+			}
+		}
+
+		return
+	}
+
+	p.cursor = cursor
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+
+	"github.com/0xPolygon/panoptichain/contracts"
+)
+
+// globalExitRootMapBackend is a stand-in for an L2 node that only answers the
+// GlobalExitRootL2 contract's globalExitRootMap calls.
+type globalExitRootMapBackend struct {
+	bind.ContractBackend
+
+	injected map[common.Hash]bool
+	calls    int
+}
+
+func (b *globalExitRootMapBackend) CallContract(ctx context.Context, call ethereum.CallMsg, block *big.Int) ([]byte, error) {
+	b.calls++
+
+	value := make([]byte, 32)
+	if b.injected[common.BytesToHash(call.Data[4:36])] {
+		value[31] = 1
+	}
+
+	return value, nil
+}
+
+func TestCheckL2GlobalExitRoots(t *testing.T) {
+	now := time.Now()
+
+	r := &RPCProvider{logger: zerolog.Nop()}
+	r.pendingGlobalExitRoots.roots = make(map[common.Hash]l1GlobalExitRoot)
+
+	// The roots are numbered newest first, and only an older one was injected
+	// on L2.
+	var hashes []common.Hash
+	for i := 0; i < 40; i++ {
+		hash := common.BigToHash(big.NewInt(int64(i + 1)))
+		hashes = append(hashes, hash)
+		r.pendingGlobalExitRoots.roots[hash] = l1GlobalExitRoot{network: "L1", time: now.Add(-time.Duration(i) * time.Minute)}
+	}
+
+	backend := &globalExitRootMapBackend{injected: map[common.Hash]bool{hashes[29]: true}}
+	contract, err := contracts.NewPolygonZkEVMGlobalExitRootL2(common.Address{1}, backend)
+	if err != nil {
+		t.Fatalf("failed to bind contract: %v", err)
+	}
+
+	r.checkL2GlobalExitRoots(contract, &bind.CallOpts{})
+	if backend.calls != maxGlobalExitRootChecks || len(r.l2GlobalExitRoots) != 0 {
+		t.Fatalf("got %d calls and %d found roots after the first refresh, want %d calls and none", backend.calls, len(r.l2GlobalExitRoots), maxGlobalExitRootChecks)
+	}
+
+	// The second refresh checks the newest root, then continues after the
+	// roots checked in the first refresh.
+	backend.calls = 0
+	r.checkL2GlobalExitRoots(contract, &bind.CallOpts{})
+	if backend.calls != 15 {
+		t.Errorf("got %d calls in the second refresh, want 15", backend.calls)
+	}
+
+	if len(r.l2GlobalExitRoots) != 1 || r.l2GlobalExitRoots[0].Hash != hashes[29] {
+		t.Fatalf("got found roots %v, want %v", r.l2GlobalExitRoots, hashes[29])
+	}
+
+	if got := len(r.pendingGlobalExitRoots.roots); got != 29 {
+		t.Errorf("got %d pending roots, want the 29 newer roots", got)
+	}
+}
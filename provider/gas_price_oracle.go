@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/0xPolygon/panoptichain/observer"
+)
+
+// defaultOracleBlocks is the number of blocks after a gas price suggestion that
+// are used to evaluate it.
+const defaultOracleBlocks = 5
+
+// gasPriceSuggestion is a gas price oracle suggestion that is waiting to be
+// compared with the prices paid in the blocks that follow it.
+type gasPriceSuggestion struct {
+	// block is the latest block number when the suggestion was fetched.
+	block    uint64
+	gasPrice *big.Int
+	// tip is nil if the network doesn't support eth_maxPriorityFeePerGas.
+	tip *big.Int
+}
+
+// getGasPriceSuggestion fetches the suggested gas price (eth_gasPrice) and
+// priority fee (eth_maxPriorityFeePerGas).
+func (r *RPCProvider) getGasPriceSuggestion(ctx context.Context, c *ethclient.Client) (*gasPriceSuggestion, error) {
+	gasPrice, err := c.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tip, err := c.SuggestGasTipCap(ctx)
+	if err != nil {
+		r.logger.Debug().Err(err).Msg("Failed to get suggested gas tip cap")
+		tip = nil
+	}
+
+	return &gasPriceSuggestion{
+		block:    r.BlockNumber,
+		gasPrice: gasPrice,
+		tip:      tip,
+	}, nil
+}
+
+// refreshGasPriceOracle compares the gas price suggestions against the effective
+// gas prices paid by the transactions in the next blocks. Suggestions are kept
+// until enough blocks have been observed.
+func (r *RPCProvider) refreshGasPriceOracle() {
+	r.gasPriceOracle = nil
+
+	if r.timeToMine == nil {
+		return
+	}
+
+	n := r.timeToMine.OracleBlocks
+	if n == 0 {
+		n = defaultOracleBlocks
+	}
+
+	var pending []*gasPriceSuggestion
+	for _, suggestion := range r.gasPriceSuggestions {
+		if r.BlockNumber < suggestion.block+n {
+			pending = append(pending, suggestion)
+			continue
+		}
+
+		prices, tips := r.getEffectiveGasPrices(suggestion.block+1, suggestion.block+n)
+
+		if oracle := newGasPriceOracle(observer.GasPriceOracleGasPrice, suggestion.gasPrice, prices); oracle != nil {
+			r.gasPriceOracle = append(r.gasPriceOracle, oracle)
+		}
+
+		if suggestion.tip == nil {
+			continue
+		}
+
+		if oracle := newGasPriceOracle(observer.GasPriceOraclePriorityFee, suggestion.tip, tips); oracle != nil {
+			r.gasPriceOracle = append(r.gasPriceOracle, oracle)
+		}
+	}
+
+	r.gasPriceSuggestions = pending
+}
+
+// getEffectiveGasPrices returns the effective gas prices and priority fees paid
+// by the transactions in the buffered blocks between start and end inclusive.
+// Priority fees are only returned for blocks with a base fee.
+func (r *RPCProvider) getEffectiveGasPrices(start, end uint64) (prices, tips []*big.Int) {
+	for i := start; i <= end; i++ {
+		b, err := r.blockBuffer.GetBlock(i)
+		if err != nil {
+			continue
+		}
+
+		block, ok := b.(*types.Block)
+		if !ok {
+			continue
+		}
+
+		baseFee := block.BaseFee()
+		for _, tx := range block.Transactions() {
+			if baseFee == nil {
+				prices = append(prices, tx.GasPrice())
+				continue
+			}
+
+			tip, err := tx.EffectiveGasTip(baseFee)
+			if err != nil {
+				continue
+			}
+
+			prices = append(prices, new(big.Int).Add(baseFee, tip))
+			tips = append(tips, tip)
+		}
+	}
+
+	return prices, tips
+}
+
+// newGasPriceOracle summarizes how the suggested price compares to the prices
+// that were paid. It returns nil if there are no paid prices.
+func newGasPriceOracle(kind string, suggested *big.Int, paid []*big.Int) *observer.GasPriceOracle {
+	if len(paid) == 0 {
+		return nil
+	}
+
+	slices.SortFunc(paid, func(a, b *big.Int) int { return a.Cmp(b) })
+
+	below, _ := slices.BinarySearchFunc(paid, suggested, func(a, b *big.Int) int { return a.Cmp(b) })
+
+	return &observer.GasPriceOracle{
+		Type:         kind,
+		Suggested:    suggested,
+		Median:       paid[len(paid)/2],
+		Transactions: len(paid),
+		PaidBelow:    float64(below) / float64(len(paid)) * 100,
+	}
+}
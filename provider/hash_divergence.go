@@ -2,6 +2,8 @@ package provider
 
 import (
 	"context"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -24,6 +26,8 @@ type HashDivergenceProvider struct {
 	label    string
 	logger   zerolog.Logger
 
+	// mu guards the providers, which are added and removed while running.
+	mu sync.Mutex
 	// networkProvidersMap maps the network name to the provider.
 	networkProvidersMap map[string][]*RPCProvider
 	// networkBlockNumbers keeps track of the latest block number that was queried
@@ -31,6 +35,9 @@ type HashDivergenceProvider struct {
 	networkBlockNumbers map[string]uint64
 	hashDivergences     []*observer.CoreMessage
 	refreshStateTime    *time.Duration
+
+	// removed are the networks whose series are deleted on the next publish.
+	removed []network.Network
 }
 
 func NewHashDivergenceProvider(rpcProviders []*RPCProvider, eb *observer.EventBus, interval uint) *HashDivergenceProvider {
@@ -54,9 +61,43 @@ func NewHashDivergenceProvider(rpcProviders []*RPCProvider, eb *observer.EventBu
 	}
 }
 
+// AddProvider starts comparing the blocks of the RPC provider.
+func (h *HashDivergenceProvider) AddProvider(p *RPCProvider) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	name := p.Network.GetName()
+	h.networkProvidersMap[name] = append(h.networkProvidersMap[name], p)
+	if _, ok := h.networkBlockNumbers[name]; !ok {
+		h.networkBlockNumbers[name] = 0
+	}
+}
+
+// RemoveProvider stops comparing the blocks of the RPC provider.
+func (h *HashDivergenceProvider) RemoveProvider(p *RPCProvider) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	name := p.Network.GetName()
+	providers := slices.DeleteFunc(h.networkProvidersMap[name], func(provider *RPCProvider) bool {
+		return provider == p
+	})
+
+	if len(providers) > 0 {
+		h.networkProvidersMap[name] = providers
+		return
+	}
+
+	delete(h.networkProvidersMap, name)
+	delete(h.networkBlockNumbers, name)
+}
+
 func (h *HashDivergenceProvider) RefreshState(context.Context) error {
 	defer timer(h.refreshStateTime)()
 
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
 	h.hashDivergences = nil
 
 loop:
@@ -125,8 +166,29 @@ loop:
 	return nil
 }
 
+// RemoveNetwork stops comparing the blocks of the network. Its series are
+// deleted on the next publish.
+func (h *HashDivergenceProvider) RemoveNetwork(n network.Network) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.networkProvidersMap, n.GetName())
+	delete(h.networkBlockNumbers, n.GetName())
+	h.hashDivergences = withoutNetwork(h.hashDivergences, n)
+	h.removed = append(h.removed, n)
+}
+
 func (h *HashDivergenceProvider) PublishEvents(ctx context.Context) error {
-	for _, m := range h.hashDivergences {
+	h.mu.Lock()
+	hashDivergences, removed := h.hashDivergences, h.removed
+	h.removed = nil
+	h.mu.Unlock()
+
+	for _, n := range removed {
+		h.bus.DeleteSeries(n, h.label)
+	}
+
+	for _, m := range hashDivergences {
 		h.bus.Publish(ctx, topics.HashDivergence, m)
 	}
 
@@ -7,8 +7,8 @@ import (
 	"math/big"
 	"net/url"
 	"reflect"
-	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -16,6 +16,7 @@ import (
 
 	"github.com/0xPolygon/panoptichain/api"
 	"github.com/0xPolygon/panoptichain/blockbuffer"
+	"github.com/0xPolygon/panoptichain/config"
 	"github.com/0xPolygon/panoptichain/network"
 	"github.com/0xPolygon/panoptichain/observer"
 	"github.com/0xPolygon/panoptichain/observer/topics"
@@ -42,19 +43,47 @@ type HeimdallProvider struct {
 
 	milestone                *observer.HeimdallMilestone
 	prevMilestoneCount       int64
-	milestoneProposers       []api.ValidatorV1
-	prevMilestoneProposers   []api.ValidatorV1
+	milestoneProposers       []api.Validator
+	prevMilestoneProposers   []api.Validator
 	missedMilestoneProposers []string
 
-	span observer.HeimdallSpan
+	span               observer.HeimdallSpan
+	spanProducerChange *observer.HeimdallSpanProducerChange
+
+	// endpoints are the Tendermint and Heimdall URL pairs to fail over between.
+	// The active endpoint's URLs and version are copied to TendermintURL,
+	// HeimdallURL, and version.
+	endpoints    []config.HeimdallURLs
+	active       int
+	staleAfter   time.Duration
+	lastProgress time.Time
+	failovers    uint64
+
+	// validatorCacheStats are the network's validator cache stats since the
+	// previous refresh, and prevValidatorCacheStats are the cumulative stats of
+	// the previous refresh.
+	validatorCacheStats     api.ValidatorCacheStats
+	prevValidatorCacheStats api.ValidatorCacheStats
+
+	consensus      *observer.HeimdallConsensus
+	roundState     string
+	roundStateTime time.Time
+
+	// validatorSet is the last Tendermint validator set used to check for
+	// missed block proposals.
+	validatorSet *heimdallValidatorSet
 
 	refreshStateTime *time.Duration
 }
 
-func NewHeimdallProvider(n network.Network, tendermintURL, heimdallURL, label string, eb *observer.EventBus, interval uint, version uint) *HeimdallProvider {
+// NewHeimdallProvider creates a Heimdall provider that uses the first healthy
+// endpoint. Endpoints without a version have it detected when they're used.
+func NewHeimdallProvider(n network.Network, endpoints []config.HeimdallURLs, label string, eb *observer.EventBus, interval uint, staleAfter time.Duration) *HeimdallProvider {
+	if staleAfter == 0 {
+		staleAfter = defaultStaleAfter
+	}
+
 	return &HeimdallProvider{
-		TendermintURL:       tendermintURL,
-		HeimdallURL:         heimdallURL,
 		Label:               label,
 		blockBuffer:         blockbuffer.NewBlockBuffer(128),
 		Network:             n,
@@ -63,7 +92,8 @@ func NewHeimdallProvider(n network.Network, tendermintURL, heimdallURL, label st
 		logger:              NewLogger(n, label),
 		checkpointProposers: orderedmap.New[string, struct{}](),
 		refreshStateTime:    new(time.Duration),
-		version:             version,
+		endpoints:           endpoints,
+		staleAfter:          staleAfter,
 	}
 }
 
@@ -76,13 +106,22 @@ func (h *HeimdallProvider) RefreshState(ctx context.Context) error {
 
 	h.logger.Debug().Msg("Refreshing Heimdall state")
 
+	h.failovers = 0
+	stats := api.GetValidatorCacheStats(h.Network)
+	h.validatorCacheStats = stats.Sub(h.prevValidatorCacheStats)
+	h.prevValidatorCacheStats = stats
 	h.refreshBlockBuffer()
+	if h.version == 0 {
+		return errors.New("no Heimdall endpoint is available")
+	}
+
 	h.refreshMilestone()
 	h.refreshMissedMilestoneProposal()
 	h.refreshCheckpoint()
 	h.refreshMissedCheckpointProposal()
-	h.refreshMissedBlockProposal()
+	h.refreshMissedBlockProposal(ctx)
 	h.refreshSpan()
+	h.refreshConsensus()
 
 	return nil
 }
@@ -163,6 +202,28 @@ func (h *HeimdallProvider) PublishEvents(ctx context.Context) error {
 		h.bus.Publish(ctx, topics.Span, m)
 	}
 
+	if h.spanProducerChange != nil {
+		m := observer.NewMessage(h.Network, h.Label, h.spanProducerChange)
+		h.bus.Publish(ctx, topics.SpanProducerChange, m)
+	}
+
+	if h.version > 0 {
+		m := observer.NewMessage(h.Network, h.Label, &observer.HeimdallEndpoint{
+			Index:     h.active,
+			Version:   h.version,
+			Failovers: h.failovers,
+		})
+		h.bus.Publish(ctx, topics.HeimdallEndpoint, m)
+	}
+
+	if h.consensus != nil {
+		m := observer.NewMessage(h.Network, h.Label, h.consensus)
+		h.bus.Publish(ctx, topics.HeimdallConsensus, m)
+	}
+
+	m := observer.NewMessage(h.Network, h.Label, h.validatorCacheStats)
+	h.bus.Publish(ctx, topics.ValidatorCache, m)
+
 	h.bus.Publish(ctx, topics.RefreshStateTime, observer.NewMessage(h.Network, h.Label, h.refreshStateTime))
 
 	return nil
@@ -174,13 +235,14 @@ func (h *HeimdallProvider) PollingInterval() uint {
 
 func (h *HeimdallProvider) refreshBlockBuffer() {
 	h.prevBlockNumber = h.BlockNumber
-	block := h.getBlock(0)
+	block := h.getLatestBlock()
 	if block == nil {
 		return
 	}
 
+	// Don't go backwards after failing over to an endpoint that is behind.
 	bn := block.Number()
-	if bn == nil {
+	if bn.Uint64() < h.BlockNumber {
 		return
 	}
 	h.BlockNumber = bn.Uint64()
@@ -212,6 +274,12 @@ func (h *HeimdallProvider) getBlock(height uint64) *observer.HeimdallBlock {
 	return &block
 }
 
+// heimdallValidatorsPerPage is the maximum page size of the Tendermint
+// validators endpoint. The default is only 30, which is fewer than the number
+// of validators on mainnet.
+const heimdallValidatorsPerPage = 100
+
+// getValidators fetches every page of the validator set at the height.
 func (h *HeimdallProvider) getValidators(height uint64) *observer.HeimdallValidators {
 	path, err := url.JoinPath(h.TendermintURL, "validators")
 	if err != nil {
@@ -219,17 +287,34 @@ func (h *HeimdallProvider) getValidators(height uint64) *observer.HeimdallValida
 		return nil
 	}
 
-	if height > 0 {
-		path = fmt.Sprintf("%s?height=%d", path, height)
-	}
-
 	var validators observer.HeimdallValidators
-	err = api.GetJSON(path, &validators)
-	if err != nil {
-		h.logger.Error().Err(err).Msg("Failed to get Heimdall validators")
-		return nil
+	for page := 1; ; page++ {
+		query := url.Values{}
+		if height > 0 {
+			query.Set("height", fmt.Sprint(height))
+		}
+		query.Set("page", fmt.Sprint(page))
+		query.Set("per_page", fmt.Sprint(heimdallValidatorsPerPage))
+
+		var response observer.HeimdallValidators
+		err = api.GetJSON(path+"?"+query.Encode(), &response)
+		if err != nil {
+			h.logger.Error().Err(err).Int("page", page).Msg("Failed to get Heimdall validators")
+			return nil
+		}
+
+		validators.Result.BlockHeight = response.Result.BlockHeight
+		validators.Result.Total = response.Result.Total
+		validators.Result.Validators = append(validators.Result.Validators, response.Validators()...)
+
+		total, err := strconv.Atoi(response.Result.Total)
+		if err != nil || len(response.Validators()) == 0 || len(validators.Validators()) >= total {
+			break
+		}
 	}
 
+	validators.Result.Count = fmt.Sprint(len(validators.Validators()))
+
 	return &validators
 }
 
@@ -250,8 +335,18 @@ func (h *HeimdallProvider) fillRange(start uint64) {
 	}
 }
 
+// milestonePath returns the v1 "milestone" or the v2 "milestones" route.
+func (h *HeimdallProvider) milestonePath(elem ...string) (string, error) {
+	route := "milestone"
+	if h.version == 2 {
+		route = "milestones"
+	}
+
+	return url.JoinPath(h.HeimdallURL, append([]string{route}, elem...)...)
+}
+
 func (h *HeimdallProvider) getHeimdallMilestoneCount() (*big.Int, error) {
-	path, err := url.JoinPath(h.HeimdallURL, "milestone/count")
+	path, err := h.milestonePath("count")
 	if err != nil {
 		h.logger.Error().Err(err).Msg("Failed to get Heimdall milestone count path")
 		return nil, err
@@ -290,7 +385,7 @@ func (h *HeimdallProvider) refreshMilestone() error {
 		return err
 	}
 
-	path, err := url.JoinPath(h.HeimdallURL, "milestone", count.String())
+	path, err := h.milestonePath(count.String())
 	if err != nil {
 		h.logger.Error().Err(err).Msg("Failed to get Heimdall milestone path")
 		return err
@@ -428,61 +523,48 @@ func (h *HeimdallProvider) refreshMissedCheckpointProposal() error {
 	return nil
 }
 
-func (h *HeimdallProvider) refreshMissedBlockProposal() error {
-	missedBlockProposal := make(observer.HeimdallMissedBlockProposal)
-	for i := h.prevBlockNumber + 1; i <= h.BlockNumber && h.prevBlockNumber != 0; i++ {
-		block := h.getBlock(i)
-		if block == nil {
-			h.logger.Debug().Msg("Failed to get current block")
-			continue
+// getMilestoneProposers returns the next milestone proposers in order.
+func (h *HeimdallProvider) getMilestoneProposers(times uint) ([]api.Validator, error) {
+	var proposers []api.Validator
+
+	switch h.version {
+	case 1:
+		path, err := url.JoinPath(h.HeimdallURL, "staking/milestoneProposer", fmt.Sprint(times))
+		if err != nil {
+			return nil, err
 		}
-		proposer := block.ProposerAddress()
 
-		v := h.getValidators(i - 1)
-		if v == nil {
-			h.logger.Debug().Msg("Failed to get validators")
-			continue
+		var v1 observer.ValidatorsV1
+		if err := api.GetJSON(path, &v1); err != nil {
+			return nil, err
 		}
-		validators := v.Validators()
 
-		// Sort validators in descending order.
-		sort.Slice(validators, func(i, j int) bool {
-			pi, _ := strconv.Atoi(validators[i].ProposerPriority)
-			pj, _ := strconv.Atoi(validators[j].ProposerPriority)
-			return pi > pj
-		})
+		for _, validator := range v1.Result {
+			proposers = append(proposers, validator)
+		}
+	case 2:
+		path, err := url.JoinPath(h.HeimdallURL, "stake/proposers", fmt.Sprint(times))
+		if err != nil {
+			return nil, err
+		}
 
-		var proposers []string
-		for _, validator := range validators {
-			if validator.Address == proposer {
-				break
-			}
-			proposers = append(proposers, validator.Address)
+		var v2 observer.HeimdallMilestoneProposersV2
+		if err := api.GetJSON(path, &v2); err != nil {
+			return nil, err
 		}
 
-		missedBlockProposal[i] = proposers
+		for _, validator := range v2.Proposers {
+			proposers = append(proposers, validator)
+		}
 	}
 
-	h.missedBlockProposal = missedBlockProposal
-
-	return nil
+	return proposers, nil
 }
 
 func (h *HeimdallProvider) refreshMissedMilestoneProposal() error {
-	if h.version != 1 {
-		return nil
-	}
-
 	h.missedMilestoneProposers = nil
 
-	path, err := url.JoinPath(h.HeimdallURL, "staking/milestoneProposer", fmt.Sprint(500))
-	if err != nil {
-		h.logger.Error().Err(err).Msg("Failed to get Heimdall milestone proposers path")
-		return err
-	}
-
-	var proposers *observer.ValidatorsV1
-	err = api.GetJSON(path, &proposers)
+	proposers, err := h.getMilestoneProposers(500)
 	if err != nil {
 		h.logger.Error().Err(err).Msg("Failed to get Heimdall milestone proposers")
 		return err
@@ -496,9 +578,9 @@ func (h *HeimdallProvider) refreshMissedMilestoneProposal() error {
 	// there is confidence that h.prevMilestoneProposers is the proposer set for
 	// h.milestone (the latest milestone) and h.milestoneProposers is the
 	// proposer set for the next milestone.
-	if !reflect.DeepEqual(proposers.Result, h.milestoneProposers) {
+	if !reflect.DeepEqual(proposers, h.milestoneProposers) {
 		h.prevMilestoneProposers = h.milestoneProposers
-		h.milestoneProposers = proposers.Result
+		h.milestoneProposers = proposers
 	}
 
 	// This checks if the there is a new milestone.
@@ -508,11 +590,11 @@ func (h *HeimdallProvider) refreshMissedMilestoneProposal() error {
 
 	for _, validator := range h.prevMilestoneProposers {
 		// Stop when we see the latest milestone.
-		if validator.Signer == h.milestone.Proposer {
+		if strings.EqualFold(validator.GetSigner(), h.milestone.Proposer) {
 			break
 		}
 
-		h.missedMilestoneProposers = append(h.missedMilestoneProposers, validator.Signer)
+		h.missedMilestoneProposers = append(h.missedMilestoneProposers, validator.GetSigner())
 	}
 
 	if len(h.missedMilestoneProposers) > 0 {
@@ -524,39 +606,121 @@ func (h *HeimdallProvider) refreshMissedMilestoneProposal() error {
 	return nil
 }
 
-func (h *HeimdallProvider) refreshSpan() error {
+// getSpan fetches a span from Heimdall. The id is either a span ID or "latest".
+func (h *HeimdallProvider) getSpan(id string) (observer.HeimdallSpan, error) {
 	switch h.version {
 	case 1:
-		url, err := url.JoinPath(h.HeimdallURL, "bor/latest-span")
+		path := "bor/span/" + id
+		if id == "latest" {
+			path = "bor/latest-span"
+		}
+
+		url, err := url.JoinPath(h.HeimdallURL, path)
 		if err != nil {
-			h.logger.Error().Err(err).Msg("Failed to get Heimdall v1 latest span path")
-			return err
+			h.logger.Error().Err(err).Msg("Failed to get Heimdall v1 span path")
+			return nil, err
 		}
 
 		var v1 observer.HeimdallSpanV1
 		err = api.GetJSON(url, &v1)
 		if err != nil {
-			h.logger.Error().Err(err).Msg("Failed to get Heimdall v1 latest span")
-			return err
+			h.logger.Error().Err(err).Str("span", id).Msg("Failed to get Heimdall v1 span")
+			return nil, err
 		}
 
-		h.span = v1
+		return v1, nil
 	case 2:
-		url, err := url.JoinPath(h.HeimdallURL, "bor/span/latest")
+		url, err := url.JoinPath(h.HeimdallURL, "bor/span", id)
 		if err != nil {
-			h.logger.Error().Err(err).Msg("Failed to get Heimdall v2 latest span path")
-			return err
+			h.logger.Error().Err(err).Msg("Failed to get Heimdall v2 span path")
+			return nil, err
 		}
 
 		var v2 observer.HeimdallSpanV2
 		err = api.GetJSON(url, &v2)
 		if err != nil {
-			h.logger.Error().Err(err).Msg("Failed to get Heimdall v2 latest span")
-			return err
+			h.logger.Error().Err(err).Str("span", id).Msg("Failed to get Heimdall v2 span")
+			return nil, err
+		}
+
+		return v2, nil
+	}
+
+	return nil, fmt.Errorf("unsupported Heimdall version: %d", h.version)
+}
+
+func (h *HeimdallProvider) refreshSpan() error {
+	h.spanProducerChange = nil
+
+	span, err := h.getSpan("latest")
+	if err != nil {
+		return err
+	}
+
+	prev := h.span
+	h.span = span
+	setSpan(h.Network, span)
+
+	if prev == nil {
+		// The latest span is usually committed before it starts, so the previous
+		// span is needed to cross-reference the current Bor blocks.
+		if span.GetID() > 0 {
+			if prev, err := h.getSpan(fmt.Sprint(span.GetID() - 1)); err == nil {
+				setSpan(h.Network, prev)
+			}
 		}
 
-		h.span = v2
+		return nil
+	}
+
+	if prev.GetID() == span.GetID() {
+		return nil
 	}
 
+	h.spanProducerChange = newSpanProducerChange(prev, span)
 	return nil
 }
+
+// setSpan stores the span and its producers so RPC providers can check whether
+// Bor blocks were authored by the span's producers.
+func setSpan(n network.Network, span observer.HeimdallSpan) {
+	producers := span.GetSelectedProducers()
+	signers := make([]string, 0, len(producers))
+	for _, producer := range producers {
+		signers = append(signers, strings.ToLower(producer.GetSigner()))
+	}
+
+	api.SetSpan(n, api.Span{
+		ID:         span.GetID(),
+		StartBlock: span.GetStartBlock(),
+		EndBlock:   span.GetEndBlock(),
+		Producers:  signers,
+	})
+}
+
+// newSpanProducerChange returns the producers that were added and removed
+// between the previous and the current span.
+func newSpanProducerChange(prev, span observer.HeimdallSpan) *observer.HeimdallSpanProducerChange {
+	change := &observer.HeimdallSpanProducerChange{SpanID: span.GetID()}
+
+	prevProducers := make(map[uint64]struct{})
+	for _, producer := range prev.GetSelectedProducers() {
+		prevProducers[producer.GetID()] = struct{}{}
+	}
+
+	producers := make(map[uint64]struct{})
+	for _, producer := range span.GetSelectedProducers() {
+		producers[producer.GetID()] = struct{}{}
+		if _, ok := prevProducers[producer.GetID()]; !ok {
+			change.Added = append(change.Added, producer)
+		}
+	}
+
+	for _, producer := range prev.GetSelectedProducers() {
+		if _, ok := producers[producer.GetID()]; !ok {
+			change.Removed = append(change.Removed, producer)
+		}
+	}
+
+	return change
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/panoptichain/api"
+	"github.com/0xPolygon/panoptichain/observer"
+)
+
+// getTendermint queries a Tendermint RPC endpoint of the active endpoint.
+func (h *HeimdallProvider) getTendermint(endpoint string, v any) error {
+	path, err := url.JoinPath(h.TendermintURL, endpoint)
+	if err != nil {
+		return err
+	}
+
+	return api.GetJSON(path, v)
+}
+
+// refreshConsensus polls the Tendermint RPC for the node's sync status, peers,
+// consensus round state, and mempool. Each endpoint is optional, so a failure
+// only leaves its fields unset.
+func (h *HeimdallProvider) refreshConsensus() {
+	consensus := &observer.HeimdallConsensus{}
+
+	var status observer.TendermintStatus
+	if err := h.getTendermint("status", &status); err != nil {
+		h.logger.Warn().Err(err).Msg("Failed to get Tendermint status")
+	} else {
+		consensus.CatchingUp = &status.Result.SyncInfo.CatchingUp
+	}
+
+	var netInfo observer.TendermintNetInfo
+	if err := h.getTendermint("net_info", &netInfo); err != nil {
+		h.logger.Warn().Err(err).Msg("Failed to get Tendermint net info")
+	} else if peers, err := strconv.ParseUint(netInfo.Result.NPeers, 10, 64); err == nil {
+		consensus.Peers = &peers
+	}
+
+	var unconfirmed observer.TendermintUnconfirmedTxs
+	if err := h.getTendermint("num_unconfirmed_txs", &unconfirmed); err != nil {
+		h.logger.Warn().Err(err).Msg("Failed to get Tendermint unconfirmed transactions")
+	} else {
+		txs, errTxs := strconv.ParseUint(unconfirmed.Result.Total, 10, 64)
+		bytes, errBytes := strconv.ParseUint(unconfirmed.Result.TotalBytes, 10, 64)
+		if errTxs == nil && errBytes == nil {
+			consensus.MempoolTxs = &txs
+			consensus.MempoolBytes = &bytes
+		}
+	}
+
+	if height, round, step, err := h.getRoundState(); err != nil {
+		h.logger.Warn().Err(err).Msg("Failed to get Tendermint consensus state")
+	} else {
+		// The step duration is measured from when the step was first observed,
+		// because Tendermint doesn't expose when the step started.
+		hrs := fmt.Sprintf("%d/%d/%d", height, round, step)
+		if hrs != h.roundState {
+			h.roundState = hrs
+			h.roundStateTime = time.Now()
+		}
+
+		consensus.RoundState = &observer.TendermintRoundState{
+			Height:       height,
+			Round:        round,
+			Step:         step,
+			StepDuration: time.Since(h.roundStateTime),
+		}
+	}
+
+	h.consensus = consensus
+}
+
+// getRoundState returns the height, round, and step of the consensus state,
+// falling back to dump_consensus_state if consensus_state isn't available.
+func (h *HeimdallProvider) getRoundState() (height, round, step uint64, err error) {
+	var state observer.TendermintConsensusState
+	if err = h.getTendermint("consensus_state", &state); err == nil {
+		return parseHeightRoundStep(state.Result.RoundState.HeightRoundStep)
+	}
+
+	var dump observer.TendermintDumpConsensusState
+	if dumpErr := h.getTendermint("dump_consensus_state", &dump); dumpErr != nil {
+		return 0, 0, 0, err
+	}
+
+	rs := dump.Result.RoundState
+	if height, err = parseJSONUint(rs.Height); err != nil {
+		return 0, 0, 0, err
+	}
+	if round, err = parseJSONUint(rs.Round); err != nil {
+		return 0, 0, 0, err
+	}
+	if step, err = parseJSONUint(rs.Step); err != nil {
+		return 0, 0, 0, err
+	}
+
+	return height, round, step, nil
+}
+
+// parseHeightRoundStep parses the "height/round/step" field of the consensus
+// state.
+func parseHeightRoundStep(hrs string) (height, round, step uint64, err error) {
+	parts := strings.Split(hrs, "/")
+	if len(parts) != 3 {
+		return 0, 0, 0, fmt.Errorf("invalid height/round/step: %q", hrs)
+	}
+
+	values := make([]uint64, len(parts))
+	for i, part := range parts {
+		if values[i], err = strconv.ParseUint(part, 10, 64); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+
+	return values[0], values[1], values[2], nil
+}
+
+// parseJSONUint parses an unsigned integer that depending on the Tendermint
+// version is encoded as either a JSON number or a string.
+func parseJSONUint(raw json.RawMessage) (uint64, error) {
+	return strconv.ParseUint(strings.Trim(string(raw), `"`), 10, 64)
+}
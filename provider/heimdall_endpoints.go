@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/0xPolygon/panoptichain/api"
+	"github.com/0xPolygon/panoptichain/config"
+	"github.com/0xPolygon/panoptichain/observer"
+)
+
+// defaultStaleAfter is how long the Heimdall height can stay the same before
+// the endpoint is considered stale.
+const defaultStaleAfter = time.Minute
+
+// detectHeimdallVersion probes the Heimdall REST API to determine its version.
+// Heimdall v1 wraps responses in a "result" object while Heimdall v2 doesn't.
+func detectHeimdallVersion(heimdallURL string) (uint, error) {
+	path, err := url.JoinPath(heimdallURL, "checkpoints/latest")
+	if err != nil {
+		return 0, err
+	}
+
+	var body map[string]json.RawMessage
+	if err := api.GetJSON(path, &body); err != nil {
+		return 0, err
+	}
+
+	if _, ok := body["result"]; ok {
+		return 1, nil
+	}
+
+	if _, ok := body["checkpoint"]; ok {
+		return 2, nil
+	}
+
+	return 0, errors.New("unable to detect Heimdall version")
+}
+
+// activateEndpoint switches to the endpoint at index i, detecting the version if
+// it isn't configured.
+func (h *HeimdallProvider) activateEndpoint(i int) error {
+	endpoint := &h.endpoints[i]
+
+	if endpoint.Version == 0 {
+		version, err := detectHeimdallVersion(endpoint.HeimdallURL)
+		if err != nil {
+			return err
+		}
+
+		h.logger.Info().Int("endpoint", i).Uint("version", version).Msg("Detected Heimdall version")
+		endpoint.Version = version
+	}
+
+	h.active = i
+	h.TendermintURL = endpoint.TendermintURL
+	h.HeimdallURL = endpoint.HeimdallURL
+	h.version = endpoint.Version
+
+	api.SetHeimdallEndpoint(h.Network, h.Label, api.HeimdallEndpoint{
+		URL:     h.HeimdallURL,
+		Version: h.version,
+	})
+
+	return nil
+}
+
+// getLatestBlock returns the latest block of the active endpoint. If the active
+// endpoint fails or its height is stale, the other endpoints are tried in order
+// and the first healthy one becomes active. If every endpoint fails or is stale,
+// the active endpoint is kept and its stale block is returned.
+func (h *HeimdallProvider) getLatestBlock() *observer.HeimdallBlock {
+	active := h.active
+	var stale *observer.HeimdallBlock
+
+	for attempt := 0; attempt < len(h.endpoints); attempt++ {
+		i := (active + attempt) % len(h.endpoints)
+
+		if attempt > 0 || h.version == 0 {
+			if err := h.activateEndpoint(i); err != nil {
+				h.logger.Warn().Err(err).Int("endpoint", i).Msg("Failed to activate Heimdall endpoint")
+				continue
+			}
+		}
+
+		block := h.getBlock(0)
+		if block == nil || block.Number() == nil {
+			continue
+		}
+
+		if h.isStale(block.Number().Uint64()) {
+			h.logger.Warn().Int("endpoint", i).Uint64("height", block.Number().Uint64()).Msg("Heimdall endpoint height is stale")
+
+			if stale == nil {
+				stale = block
+			}
+			continue
+		}
+
+		if i != active {
+			h.failovers++
+			h.logger.Warn().Int("endpoint", i).Msg("Failed over to Heimdall endpoint")
+		}
+
+		return block
+	}
+
+	// An endpoint that is just as stale isn't any healthier, so don't fail over
+	// to it, e.g. while the chain is halted.
+	if h.active != active {
+		if err := h.activateEndpoint(active); err != nil {
+			h.logger.Warn().Err(err).Int("endpoint", active).Msg("Failed to activate Heimdall endpoint")
+		}
+	}
+
+	return stale
+}
+
+// isStale returns whether the height hasn't increased within the stale period.
+// Progress is only made when an endpoint reports a height above the last one
+// seen, so failing over doesn't reset it.
+func (h *HeimdallProvider) isStale(height uint64) bool {
+	if height > h.BlockNumber {
+		h.lastProgress = time.Now()
+		return false
+	}
+
+	return time.Since(h.lastProgress) > h.staleAfter
+}
+
+// NewHeimdallEndpoints combines the single Tendermint and Heimdall URL pair with
+// the list of endpoints.
+func NewHeimdallEndpoints(cfg config.HeimdallEndpoint) []config.HeimdallURLs {
+	var endpoints []config.HeimdallURLs
+	if len(cfg.TendermintURL) > 0 && len(cfg.HeimdallURL) > 0 {
+		endpoints = append(endpoints, config.HeimdallURLs{
+			TendermintURL: cfg.TendermintURL,
+			HeimdallURL:   cfg.HeimdallURL,
+			Version:       cfg.Version,
+		})
+	}
+
+	for _, endpoint := range cfg.Endpoints {
+		if endpoint.Version == 0 {
+			endpoint.Version = cfg.Version
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	return endpoints
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/0xPolygon/panoptichain/observer"
+)
+
+// newValidatorsNode is a stand-in for the Tendermint validators endpoint that
+// pages through total validators like Tendermint does.
+func newValidatorsNode(t *testing.T, total int) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	var requests []string
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		requests = append(requests, req.URL.RawQuery)
+		mu.Unlock()
+
+		query := req.URL.Query()
+		page, _ := strconv.Atoi(query.Get("page"))
+		perPage, _ := strconv.Atoi(query.Get("per_page"))
+		if page < 1 || perPage < 1 || (page-1)*perPage >= max(total, 1) {
+			http.Error(w, "page out of range", http.StatusInternalServerError)
+			return
+		}
+
+		var response observer.HeimdallValidators
+		response.Result.BlockHeight = query.Get("height")
+		response.Result.Total = fmt.Sprint(total)
+
+		for i := (page - 1) * perPage; i < min(page*perPage, total); i++ {
+			response.Result.Validators = append(response.Result.Validators, &observer.HeimdallValidator{
+				Address:          fmt.Sprintf("%040X", i),
+				VotingPower:      "1",
+				ProposerPriority: "0",
+			})
+		}
+		response.Result.Count = fmt.Sprint(len(response.Result.Validators))
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Errorf("failed to encode response: %v", err)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return s, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return requests
+	}
+}
+
+func TestHeimdallGetValidators(t *testing.T) {
+	tests := []struct {
+		name  string
+		total int
+		pages int
+	}{
+		{name: "single page", total: 30, pages: 1},
+		{name: "full page", total: 100, pages: 1},
+		{name: "mainnet", total: 105, pages: 2},
+		{name: "three pages", total: 250, pages: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, requests := newValidatorsNode(t, tt.total)
+			h := &HeimdallProvider{TendermintURL: s.URL, logger: zerolog.Nop()}
+
+			v := h.getValidators(10)
+			if v == nil {
+				t.Fatal("validators are nil")
+			}
+
+			if len(v.Validators()) != tt.total || v.Result.Count != fmt.Sprint(tt.total) {
+				t.Errorf("got %d validators with count %s, want %d", len(v.Validators()), v.Result.Count, tt.total)
+			}
+
+			queries := requests()
+			if len(queries) != tt.pages {
+				t.Fatalf("got %d requests %v, want %d", len(queries), queries, tt.pages)
+			}
+
+			if queries[0] != "height=10&page=1&per_page=100" {
+				t.Errorf("unexpected query %s", queries[0])
+			}
+
+			set := newHeimdallValidatorSet(10, "hash", v)
+			if !set.complete {
+				t.Error("validator set isn't complete")
+			}
+		})
+	}
+}
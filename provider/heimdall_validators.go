@@ -0,0 +1,314 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/panoptichain/observer"
+)
+
+// heimdallValidatorWorkers is the maximum number of concurrent validator set
+// requests when checking for missed block proposals.
+const heimdallValidatorWorkers = 8
+
+// maxValidatorSetAdvance is the maximum number of heights a validator set is
+// derived locally before it is fetched again.
+const maxValidatorSetAdvance = 64
+
+type heimdallValidator struct {
+	address  string
+	power    int64
+	priority int64
+}
+
+// heimdallValidatorSet is a Tendermint validator set at a height, identified by
+// the validators hash of the block at that height.
+type heimdallValidatorSet struct {
+	height     uint64
+	hash       string
+	validators []heimdallValidator
+
+	// fetched is the height the validator set was last fetched at. Validator
+	// sets are only derived up to maxValidatorSetAdvance heights after it.
+	fetched uint64
+
+	// complete is whether the response contained every validator. Incomplete
+	// validator sets can't be used to derive the next height's priorities.
+	complete bool
+}
+
+func newHeimdallValidatorSet(height uint64, hash string, v *observer.HeimdallValidators) *heimdallValidatorSet {
+	set := &heimdallValidatorSet{
+		height:   height,
+		hash:     hash,
+		fetched:  height,
+		complete: len(hash) > 0,
+	}
+
+	for _, validator := range v.Validators() {
+		power, err := strconv.ParseInt(validator.VotingPower, 10, 64)
+		if err != nil {
+			set.complete = false
+		}
+
+		priority, _ := strconv.ParseInt(validator.ProposerPriority, 10, 64)
+
+		set.validators = append(set.validators, heimdallValidator{
+			address:  strings.ToUpper(validator.Address),
+			power:    power,
+			priority: priority,
+		})
+	}
+
+	if total, err := strconv.Atoi(v.Result.Total); err == nil && total != len(set.validators) {
+		set.complete = false
+	}
+
+	return set
+}
+
+// next derives the validator set of the next height when its validators hash is
+// unchanged, which only changes the proposer priorities. This is the same as
+// Tendermint's IncrementProposerPriority(1).
+func (s *heimdallValidatorSet) next(hash string) *heimdallValidatorSet {
+	next := &heimdallValidatorSet{
+		height:     s.height + 1,
+		hash:       hash,
+		validators: make([]heimdallValidator, len(s.validators)),
+		fetched:    s.fetched,
+		complete:   s.complete,
+	}
+	copy(next.validators, s.validators)
+
+	var total int64
+	for _, v := range next.validators {
+		total += v.power
+	}
+
+	if len(next.validators) == 0 {
+		return next
+	}
+
+	// Rescale the priorities so the difference between the highest and lowest
+	// priorities is at most twice the total voting power.
+	low, high := next.validators[0].priority, next.validators[0].priority
+	for _, v := range next.validators {
+		low = min(low, v.priority)
+		high = max(high, v.priority)
+	}
+
+	window := 2 * total
+	if diff := high - low; window > 0 && diff > window {
+		ratio := (diff + window - 1) / window
+		for i := range next.validators {
+			next.validators[i].priority /= ratio
+		}
+	}
+
+	// Center the priorities around zero.
+	sum := new(big.Int)
+	for _, v := range next.validators {
+		sum.Add(sum, big.NewInt(v.priority))
+	}
+	avg := sum.Div(sum, big.NewInt(int64(len(next.validators)))).Int64()
+
+	for i := range next.validators {
+		next.validators[i].priority -= avg
+	}
+
+	// Increment the priorities and choose the proposer.
+	proposer := 0
+	for i := range next.validators {
+		next.validators[i].priority += next.validators[i].power
+		if next.validators[i].hasPriorityOver(next.validators[proposer]) {
+			proposer = i
+		}
+	}
+	next.validators[proposer].priority -= total
+
+	return next
+}
+
+// hasPriorityOver returns whether the validator has a higher proposer priority
+// than the other validator, breaking ties by address.
+func (v heimdallValidator) hasPriorityOver(other heimdallValidator) bool {
+	if v.priority != other.priority {
+		return v.priority > other.priority
+	}
+
+	return v.address < other.address
+}
+
+// missedProposers returns the validators with a higher proposer priority than
+// the actual proposer.
+func (s *heimdallValidatorSet) missedProposers(proposer string) []string {
+	validators := make([]heimdallValidator, len(s.validators))
+	copy(validators, s.validators)
+
+	sort.Slice(validators, func(i, j int) bool {
+		return validators[i].hasPriorityOver(validators[j])
+	})
+
+	var proposers []string
+	for _, validator := range validators {
+		if strings.EqualFold(validator.address, proposer) {
+			break
+		}
+		proposers = append(proposers, validator.address)
+	}
+
+	return proposers
+}
+
+// getBufferedBlock returns the block from the block buffer, only fetching it if
+// it isn't buffered.
+func (h *HeimdallProvider) getBufferedBlock(height uint64) *observer.HeimdallBlock {
+	if b, err := h.blockBuffer.GetBlock(height); err == nil {
+		if block, ok := b.(*observer.HeimdallBlock); ok {
+			return block
+		}
+	}
+
+	return h.getBlock(height)
+}
+
+// getValidatorSets fetches the validator sets at the heights concurrently. The
+// hashes map the heights to their validators hash. Heights that aren't fetched
+// before the context is done are skipped.
+func (h *HeimdallProvider) getValidatorSets(ctx context.Context, heights []uint64, hashes map[uint64]string) map[uint64]*heimdallValidatorSet {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sets := make(map[uint64]*heimdallValidatorSet)
+	jobs := make(chan uint64)
+
+	for w := 0; w < min(heimdallValidatorWorkers, len(heights)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for height := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+
+				v := h.getValidators(height)
+				if v == nil {
+					continue
+				}
+
+				set := newHeimdallValidatorSet(height, hashes[height], v)
+
+				mu.Lock()
+				sets[height] = set
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, height := range heights {
+		jobs <- height
+	}
+	close(jobs)
+	wg.Wait()
+
+	return sets
+}
+
+// refreshMissedBlockProposal finds the validators that should have proposed
+// each new block before its actual proposer, using the validator set of the
+// previous height. Blocks are reused from the block buffer. Validator sets
+// whose validators hash is unchanged from the previous height are derived
+// locally, and the rest are fetched concurrently. The refresh is bounded by the
+// polling interval.
+func (h *HeimdallProvider) refreshMissedBlockProposal(ctx context.Context) error {
+	missedBlockProposal := make(observer.HeimdallMissedBlockProposal)
+	h.missedBlockProposal = missedBlockProposal
+
+	if h.prevBlockNumber == 0 || h.BlockNumber <= h.prevBlockNumber {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(h.interval)*time.Second)
+	defer cancel()
+
+	start, end := h.prevBlockNumber+1, h.BlockNumber
+
+	blocks := make(map[uint64]*observer.HeimdallBlock)
+	hashes := make(map[uint64]string)
+	for i := start - 1; i <= end; i++ {
+		block := h.getBufferedBlock(i)
+		if block == nil {
+			continue
+		}
+
+		blocks[i] = block
+		hashes[i] = block.ValidatorsHash()
+	}
+
+	// Plan which validator sets have to be fetched, assuming the fetched sets
+	// will be complete.
+	var heights []uint64
+	last := h.validatorSet
+	for height := start - 1; height < end; height++ {
+		if last != nil && last.complete && last.height+1 == height &&
+			len(hashes[height]) > 0 && hashes[height] == last.hash &&
+			height-last.fetched < maxValidatorSetAdvance {
+			last = &heimdallValidatorSet{height: height, hash: last.hash, fetched: last.fetched, complete: true}
+			continue
+		}
+
+		heights = append(heights, height)
+		last = &heimdallValidatorSet{height: height, hash: hashes[height], fetched: height, complete: len(hashes[height]) > 0}
+	}
+
+	sets := h.getValidatorSets(ctx, heights, hashes)
+
+	h.logger.Debug().
+		Uint64("start_block", start).
+		Uint64("end_block", end).
+		Int("fetched_validator_sets", len(sets)).
+		Msg("Checking for missed block proposals")
+
+	set := h.validatorSet
+	for i := start; i <= end; i++ {
+		height := i - 1
+
+		switch {
+		case sets[height] != nil:
+			set = sets[height]
+		case set != nil && set.complete && set.height+1 == height && hashes[height] == set.hash:
+			set = set.next(hashes[height])
+		case ctx.Err() == nil:
+			// The planned validator set couldn't be derived, so fall back to
+			// fetching it.
+			v := h.getValidators(height)
+			if v == nil {
+				h.logger.Debug().Msg("Failed to get validators")
+				set = nil
+				continue
+			}
+			set = newHeimdallValidatorSet(height, hashes[height], v)
+		default:
+			h.logger.Warn().Uint64("block_number", i).Msg("Skipping missed block proposal check past the polling interval")
+			set = nil
+			continue
+		}
+
+		block, ok := blocks[i]
+		if !ok {
+			h.logger.Debug().Msg("Failed to get current block")
+			continue
+		}
+
+		missedBlockProposal[i] = set.missedProposers(block.ProposerAddress())
+	}
+
+	h.validatorSet = set
+
+	return nil
+}
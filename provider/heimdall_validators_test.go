@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newTestValidatorSet builds a complete validator set from alternating
+// addresses and voting powers, starting with zero proposer priorities.
+func newTestValidatorSet(validators ...any) *heimdallValidatorSet {
+	set := &heimdallValidatorSet{hash: "hash", complete: true}
+	for i := 0; i+1 < len(validators); i += 2 {
+		set.validators = append(set.validators, heimdallValidator{
+			address: validators[i].(string),
+			power:   int64(validators[i+1].(int)),
+		})
+	}
+
+	return set
+}
+
+func (s *heimdallValidatorSet) priorities() []int64 {
+	priorities := make([]int64, len(s.validators))
+	for i, v := range s.validators {
+		priorities[i] = v.priority
+	}
+
+	return priorities
+}
+
+// proposer returns the validator chosen by next, which is the only validator
+// whose priority was decreased by the total voting power.
+func (s *heimdallValidatorSet) proposer(prev *heimdallValidatorSet) int {
+	proposer := 0
+	for i := range s.validators {
+		if s.validators[i].priority-prev.validators[i].priority-s.validators[i].power <
+			s.validators[proposer].priority-prev.validators[proposer].priority-s.validators[proposer].power {
+			proposer = i
+		}
+	}
+
+	return proposer
+}
+
+// proposers steps the validator set n times and returns the proposer indexes.
+func proposers(set *heimdallValidatorSet, n int) (*heimdallValidatorSet, []int) {
+	var indexes []int
+	for i := 0; i < n; i++ {
+		next := set.next(set.hash)
+		indexes = append(indexes, next.proposer(set))
+		set = next
+	}
+
+	return set, indexes
+}
+
+// The expected values below are from CometBFT's validator set tests.
+func TestHeimdallValidatorSetProposerSelection(t *testing.T) {
+	set := newTestValidatorSet("666F6F", 1000, "626172", 300, "62617A", 330)
+	names := map[string]string{"666F6F": "foo", "626172": "bar", "62617A": "baz"}
+
+	_, indexes := proposers(set, 99)
+
+	var got []string
+	for _, i := range indexes {
+		got = append(got, names[set.validators[i].address])
+	}
+
+	want := "foo baz foo bar foo foo baz foo bar foo foo baz foo foo bar foo baz foo foo bar " +
+		"foo foo baz foo bar foo foo baz foo bar foo foo baz foo foo bar foo baz foo foo bar " +
+		"foo baz foo foo bar foo baz foo foo bar foo baz foo foo foo baz bar foo foo foo baz " +
+		"foo bar foo foo baz foo bar foo foo baz foo bar foo foo baz foo bar foo foo baz foo " +
+		"foo bar foo baz foo foo bar foo baz foo foo bar foo baz foo foo"
+	if strings.Join(got, " ") != want {
+		t.Errorf("got proposers %s, want %s", strings.Join(got, " "), want)
+	}
+}
+
+func TestHeimdallValidatorSetProposerSelectionByPower(t *testing.T) {
+	addr := func(i int) string { return fmt.Sprintf("%040X", i) }
+
+	tests := []struct {
+		name   string
+		powers []int
+		steps  int
+		want   []int
+	}{
+		{name: "equal power", powers: []int{100, 100, 100}, steps: 6, want: []int{0, 1, 2, 0, 1, 2}},
+		{name: "higher power", powers: []int{100, 100, 400}, steps: 2, want: []int{2, 0}},
+		{name: "higher power with remainder", powers: []int{100, 100, 401}, steps: 3, want: []int{2, 2, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := newTestValidatorSet(addr(0), tt.powers[0], addr(1), tt.powers[1], addr(2), tt.powers[2])
+			if _, got := proposers(set, tt.steps); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got proposers %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("proportional to power", func(t *testing.T) {
+		set := newTestValidatorSet(addr(0), 4, addr(1), 5, addr(2), 3)
+		_, indexes := proposers(set, 120)
+
+		counts := make([]int, 3)
+		for _, i := range indexes {
+			counts[i]++
+		}
+
+		if want := []int{40, 50, 30}; !reflect.DeepEqual(counts, want) {
+			t.Errorf("got proposer counts %v, want %v", counts, want)
+		}
+	})
+}
+
+func TestHeimdallValidatorSetPriorities(t *testing.T) {
+	set := newTestValidatorSet("00", 10, "01", 1, "02", 1)
+
+	want := []struct {
+		priorities []int64
+		proposer   int
+	}{
+		{[]int64{-2, 1, 1}, 0},
+		{[]int64{-4, 2, 2}, 0},
+		{[]int64{-6, 3, 3}, 0},
+		{[]int64{-8, 4, 4}, 0},
+		{[]int64{2, -7, 5}, 1},
+		{[]int64{0, -6, 6}, 0},
+		{[]int64{-2, -5, 7}, 0},
+		{[]int64{-4, -4, 8}, 0},
+		{[]int64{6, -3, -3}, 2},
+		{[]int64{4, -2, -2}, 0},
+		{[]int64{2, -1, -1}, 0},
+	}
+
+	for i, w := range want {
+		next := set.next(set.hash)
+		if got := next.priorities(); !reflect.DeepEqual(got, w.priorities) {
+			t.Fatalf("step %d: got priorities %v, want %v", i, got, w.priorities)
+		}
+		if got := next.proposer(set); got != w.proposer {
+			t.Fatalf("step %d: got proposer %d, want %d", i, got, w.proposer)
+		}
+		set = next
+	}
+}
+
+func TestHeimdallValidatorSetRescale(t *testing.T) {
+	tests := []struct {
+		name       string
+		powers     []int64
+		priorities []int64
+		want       [][]int64
+	}{
+		{
+			name:       "zero power",
+			powers:     []int64{0, 0, 0},
+			priorities: []int64{100, -10, 1},
+			want:       [][]int64{{70, -40, -29}},
+		},
+		{
+			name:       "wide window",
+			powers:     []int64{1, 1},
+			priorities: []int64{100, -100},
+			want:       [][]int64{{1, -1}},
+		},
+		{
+			name:       "uneven power",
+			powers:     []int64{7, 3, 5, 1},
+			priorities: []int64{1000, -250, -733, 17},
+			want: [][]int64{
+				{9, -1, -8, 1},
+				{0, 2, -3, 2},
+				{-9, 5, 2, 3},
+				{-2, -8, 7, 4},
+				{5, -5, -4, 5},
+				{-4, -2, 1, 6},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &heimdallValidatorSet{hash: "hash", complete: true}
+			for i := range tt.powers {
+				set.validators = append(set.validators, heimdallValidator{
+					address:  fmt.Sprintf("%02X", i+10),
+					power:    tt.powers[i],
+					priority: tt.priorities[i],
+				})
+			}
+
+			for i, want := range tt.want {
+				set = set.next(set.hash)
+				if got := set.priorities(); !reflect.DeepEqual(got, want) {
+					t.Fatalf("step %d: got priorities %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
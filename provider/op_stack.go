@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/0xPolygon/panoptichain/config"
+	"github.com/0xPolygon/panoptichain/contracts"
+	"github.com/0xPolygon/panoptichain/observer"
+	"github.com/0xPolygon/panoptichain/observer/topics"
+)
+
+// opBlockRef is the subset of an OP Stack block reference that's used.
+type opBlockRef struct {
+	Number   uint64 `json:"number"`
+	L1Origin struct {
+		Number uint64 `json:"number"`
+	} `json:"l1origin"`
+}
+
+// opSyncStatus is the subset of the optimism_syncStatus response that's used.
+type opSyncStatus struct {
+	HeadL1      opBlockRef `json:"head_l1"`
+	UnsafeL2    opBlockRef `json:"unsafe_l2"`
+	SafeL2      opBlockRef `json:"safe_l2"`
+	FinalizedL2 opBlockRef `json:"finalized_l2"`
+}
+
+// opStackProfile refreshes the rollup node's sync status, and the output
+// proposals and the batcher and proposer balances on L1.
+type opStackProfile struct {
+	r      *RPCProvider
+	config config.OPStack
+
+	syncStatus *observer.OPSyncStatus
+	proposals  *observer.OPOutputProposals
+	balances   []observer.OPBalance
+
+	// latest is the index of the latest output proposal of the previous
+	// refresh, which is used to count the output proposals between refreshes.
+	latest *uint64
+
+	// The clients are dialed on the first refresh and reused after that.
+	l1         *ethclient.Client
+	rollupNode *rpc.Client
+}
+
+func newOPStackProfile(r *RPCProvider, opts RPCProviderOpts) chainProfile {
+	p := &opStackProfile{r: r}
+	if opts.OPStack != nil {
+		p.config = *opts.OPStack
+	}
+
+	return p
+}
+
+func (p *opStackProfile) refresh(ctx context.Context, c *ethclient.Client) {
+	p.syncStatus = nil
+	p.proposals = nil
+	p.balances = nil
+
+	p.refreshSyncStatus(ctx)
+
+	if len(p.config.L1URL) == 0 {
+		return
+	}
+
+	if p.l1 == nil {
+		l1, err := ethclient.DialContext(ctx, p.config.L1URL)
+		if err != nil {
+			p.r.logger.Error().Err(err).Msg("Failed to create the L1 client")
+			return
+		}
+		p.l1 = l1
+	}
+
+	co := &bind.CallOpts{Context: ctx}
+	p.refreshOutputProposals(p.l1, co)
+	p.refreshBalances(ctx, p.l1, co)
+}
+
+func (p *opStackProfile) refreshSyncStatus(ctx context.Context) {
+	if len(p.config.RollupNodeURL) == 0 {
+		return
+	}
+
+	if p.rollupNode == nil {
+		client, err := rpc.DialContext(ctx, p.config.RollupNodeURL)
+		if err != nil {
+			p.r.logger.Error().Err(err).Msg("Failed to create the rollup node client")
+			return
+		}
+		p.rollupNode = client
+	}
+
+	var status opSyncStatus
+	if err := p.rollupNode.CallContext(ctx, &status, "optimism_syncStatus"); err != nil {
+		p.r.logger.Error().Err(err).Msg("Failed to get sync status")
+		return
+	}
+
+	var lag uint64
+	if status.HeadL1.Number > status.UnsafeL2.L1Origin.Number {
+		lag = status.HeadL1.Number - status.UnsafeL2.L1Origin.Number
+	}
+
+	p.syncStatus = &observer.OPSyncStatus{
+		L2Heads: map[string]uint64{
+			observer.OPUnsafeHead:    status.UnsafeL2.Number,
+			observer.OPSafeHead:      status.SafeL2.Number,
+			observer.OPFinalizedHead: status.FinalizedL2.Number,
+		},
+		L1Head:      status.HeadL1.Number,
+		L1OriginLag: lag,
+	}
+}
+
+// refreshOutputProposals gets the latest output proposal from the dispute game
+// factory, or from the L2 output oracle for chains without fault proofs.
+func (p *opStackProfile) refreshOutputProposals(c *ethclient.Client, co *bind.CallOpts) {
+	var proposals *observer.OPOutputProposals
+
+	switch {
+	case p.config.DisputeGameFactoryAddress != nil:
+		proposals = p.getDisputeGames(c, co)
+	case p.config.L2OutputOracleAddress != nil:
+		proposals = p.getL2Outputs(c, co)
+	}
+
+	if proposals == nil {
+		return
+	}
+
+	if p.latest != nil && proposals.Latest > *p.latest {
+		proposals.Observed = proposals.Latest - *p.latest
+	}
+
+	p.latest = &proposals.Latest
+	p.proposals = proposals
+}
+
+func (p *opStackProfile) getDisputeGames(c *ethclient.Client, co *bind.CallOpts) *observer.OPOutputProposals {
+	address := common.HexToAddress(*p.config.DisputeGameFactoryAddress)
+	contract, err := contracts.NewDisputeGameFactory(address, c)
+	if err != nil {
+		p.r.logger.Error().Err(err).Msg("Failed to bind dispute game factory contract")
+		return nil
+	}
+
+	count, err := contract.GameCount(co)
+	if err != nil {
+		p.r.logger.Error().Err(err).Msg("Failed to get dispute game count")
+		return nil
+	}
+
+	if count.Sign() == 0 {
+		return nil
+	}
+
+	latest := new(big.Int).Sub(count, big.NewInt(1))
+	game, err := contract.GameAtIndex(co, latest)
+	if err != nil {
+		p.r.logger.Error().Err(err).Msg("Failed to get latest dispute game")
+		return nil
+	}
+
+	return &observer.OPOutputProposals{
+		Latest: latest.Uint64(),
+		Time:   time.Unix(int64(game.Timestamp), 0),
+	}
+}
+
+func (p *opStackProfile) getL2Outputs(c *ethclient.Client, co *bind.CallOpts) *observer.OPOutputProposals {
+	address := common.HexToAddress(*p.config.L2OutputOracleAddress)
+	contract, err := contracts.NewL2OutputOracle(address, c)
+	if err != nil {
+		p.r.logger.Error().Err(err).Msg("Failed to bind L2 output oracle contract")
+		return nil
+	}
+
+	// This reverts if there are no output proposals.
+	latest, err := contract.LatestOutputIndex(co)
+	if err != nil {
+		p.r.logger.Error().Err(err).Msg("Failed to get latest output index")
+		return nil
+	}
+
+	output, err := contract.GetL2Output(co, latest)
+	if err != nil {
+		p.r.logger.Error().Err(err).Msg("Failed to get latest output proposal")
+		return nil
+	}
+
+	block := output.L2BlockNumber.Uint64()
+	return &observer.OPOutputProposals{
+		Latest:  latest.Uint64(),
+		L2Block: &block,
+		Time:    time.Unix(output.Timestamp.Int64(), 0),
+	}
+}
+
+// refreshBalances gets the L1 balances of the batcher and proposer. The
+// proposer defaults to the L2 output oracle's proposer.
+func (p *opStackProfile) refreshBalances(ctx context.Context, c *ethclient.Client, co *bind.CallOpts) {
+	accounts := make(map[string]common.Address)
+
+	if p.config.BatcherAddress != nil {
+		accounts[observer.OPBatcher] = common.HexToAddress(*p.config.BatcherAddress)
+	}
+
+	if p.config.ProposerAddress != nil {
+		accounts[observer.OPProposer] = common.HexToAddress(*p.config.ProposerAddress)
+	} else if p.config.L2OutputOracleAddress != nil && p.config.DisputeGameFactoryAddress == nil {
+		address := common.HexToAddress(*p.config.L2OutputOracleAddress)
+		if contract, err := contracts.NewL2OutputOracle(address, c); err == nil {
+			if proposer, err := contract.Proposer(co); err == nil {
+				accounts[observer.OPProposer] = proposer
+			}
+		}
+	}
+
+	for role, address := range accounts {
+		balance, err := c.BalanceAt(ctx, address, nil)
+		if err != nil {
+			p.r.logger.Error().Err(err).Any("address", address).Str("role", role).Msg("Failed to get balance")
+			continue
+		}
+
+		p.balances = append(p.balances, observer.OPBalance{Role: role, Address: address, Balance: balance})
+	}
+}
+
+func (p *opStackProfile) publish(ctx context.Context) {
+	if p.syncStatus != nil {
+		m := observer.NewMessage(p.r.Network, p.r.Label, p.syncStatus)
+		p.r.bus.Publish(ctx, topics.OPSyncStatus, m)
+	}
+
+	if p.proposals != nil {
+		m := observer.NewMessage(p.r.Network, p.r.Label, p.proposals)
+		p.r.bus.Publish(ctx, topics.OPOutputProposals, m)
+	}
+
+	if len(p.balances) > 0 {
+		m := observer.NewMessage(p.r.Network, p.r.Label, p.balances)
+		p.r.bus.Publish(ctx, topics.OPBalances, m)
+	}
+}
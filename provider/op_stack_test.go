@@ -0,0 +1,446 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+
+	"github.com/0xPolygon/panoptichain/config"
+	"github.com/0xPolygon/panoptichain/contracts"
+	"github.com/0xPolygon/panoptichain/observer"
+)
+
+// opStackNode is a scripted stand-in for an OP Stack rollup node and its L1
+// node. Contract calls are answered by method name from the packed results.
+// Requests are served over HTTP, or over WebSocket so that dials can be counted.
+type opStackNode struct {
+	t *testing.T
+
+	mu         sync.Mutex
+	syncStatus string
+	results    map[string][]any
+	balances   map[common.Address]*big.Int
+	calls      map[string]int
+	dials      int
+}
+
+type opStackRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+func newOPStackNode(t *testing.T) (*opStackNode, *httptest.Server) {
+	n := &opStackNode{
+		t:        t,
+		results:  make(map[string][]any),
+		balances: make(map[common.Address]*big.Int),
+		calls:    make(map[string]int),
+	}
+
+	s := httptest.NewServer(http.HandlerFunc(n.serve))
+	t.Cleanup(s.Close)
+
+	return n, s
+}
+
+func (n *opStackNode) set(method string, results ...any) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.results[method] = results
+}
+
+func (n *opStackNode) called(method string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.calls[method]
+}
+
+func (n *opStackNode) dialed() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.dials
+}
+
+func (n *opStackNode) serve(w http.ResponseWriter, req *http.Request) {
+	if websocket.IsWebSocketUpgrade(req) {
+		n.serveWebsocket(w, req)
+		return
+	}
+
+	var msg opStackRequest
+	if err := json.NewDecoder(req.Body).Decode(&msg); err != nil {
+		n.t.Errorf("failed to decode request: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(n.handle(msg)); err != nil {
+		n.t.Errorf("failed to encode response: %v", err)
+	}
+}
+
+func (n *opStackNode) serveWebsocket(w http.ResponseWriter, req *http.Request) {
+	var upgrader websocket.Upgrader
+	conn, err := upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		n.t.Errorf("failed to upgrade connection: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	n.mu.Lock()
+	n.dials++
+	n.mu.Unlock()
+
+	for {
+		var msg opStackRequest
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+
+		if err := conn.WriteJSON(n.handle(msg)); err != nil {
+			return
+		}
+	}
+}
+
+func (n *opStackNode) handle(msg opStackRequest) map[string]any {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.calls[msg.Method]++
+
+	var result any
+	switch msg.Method {
+	case "optimism_syncStatus":
+		result = json.RawMessage(n.syncStatus)
+
+	case "eth_getBalance":
+		var address common.Address
+		if err := json.Unmarshal(msg.Params[0], &address); err != nil {
+			n.t.Errorf("failed to decode address: %v", err)
+		}
+		result = (*hexutil.Big)(n.balances[address])
+
+	case "eth_call":
+		var call struct {
+			Input hexutil.Bytes `json:"input"`
+			Data  hexutil.Bytes `json:"data"`
+		}
+		if err := json.Unmarshal(msg.Params[0], &call); err != nil {
+			n.t.Errorf("failed to decode call: %v", err)
+		}
+
+		input := call.Input
+		if len(input) == 0 {
+			input = call.Data
+		}
+
+		method, results := n.method(input)
+		if method == nil {
+			return reply(msg.ID, nil, "execution reverted")
+		}
+
+		n.calls[method.Name]++
+		packed, err := method.Outputs.Pack(results...)
+		if err != nil {
+			n.t.Errorf("failed to pack %s results: %v", method.Name, err)
+		}
+		result = hexutil.Bytes(packed)
+
+	default:
+		return reply(msg.ID, nil, "method not found")
+	}
+
+	return reply(msg.ID, result, "")
+}
+
+// method finds the scripted contract method by its selector.
+func (n *opStackNode) method(input []byte) (*abi.Method, []any) {
+	for _, metadata := range []*bind.MetaData{contracts.L2OutputOracleMetaData, contracts.DisputeGameFactoryMetaData} {
+		parsed, err := metadata.GetAbi()
+		if err != nil {
+			n.t.Fatalf("failed to parse ABI: %v", err)
+		}
+
+		method, err := parsed.MethodById(input)
+		if err != nil {
+			continue
+		}
+
+		if results, ok := n.results[method.Name]; ok {
+			return method, results
+		}
+	}
+
+	return nil, nil
+}
+
+func reply(id json.RawMessage, result any, message string) map[string]any {
+	res := map[string]any{"jsonrpc": "2.0", "id": id}
+	if len(message) > 0 {
+		res["error"] = map[string]any{"code": -32000, "message": message}
+	} else {
+		res["result"] = result
+	}
+
+	return res
+}
+
+func newTestOPStackProfile(t *testing.T, cfg config.OPStack) *opStackProfile {
+	r := &RPCProvider{logger: zerolog.Nop()}
+	p := newOPStackProfile(r, RPCProviderOpts{OPStack: &cfg}).(*opStackProfile)
+
+	t.Cleanup(func() {
+		if p.l1 != nil {
+			p.l1.Close()
+		}
+		if p.rollupNode != nil {
+			p.rollupNode.Close()
+		}
+	})
+
+	return p
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestOPStackSyncStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		syncStatus string
+		want       observer.OPSyncStatus
+	}{
+		{
+			name: "lagging",
+			syncStatus: `{
+				"head_l1": {"hash": "0x01", "number": 1000},
+				"unsafe_l2": {"hash": "0x02", "number": 5000, "l1origin": {"hash": "0x03", "number": 990}},
+				"safe_l2": {"hash": "0x04", "number": 4900, "l1origin": {"hash": "0x05", "number": 980}},
+				"finalized_l2": {"hash": "0x06", "number": 4000, "l1origin": {"hash": "0x07", "number": 900}}
+			}`,
+			want: observer.OPSyncStatus{
+				L2Heads: map[string]uint64{
+					observer.OPUnsafeHead:    5000,
+					observer.OPSafeHead:      4900,
+					observer.OPFinalizedHead: 4000,
+				},
+				L1Head:      1000,
+				L1OriginLag: 10,
+			},
+		},
+		{
+			name: "origin ahead of head",
+			syncStatus: `{
+				"head_l1": {"number": 1000},
+				"unsafe_l2": {"number": 5000, "l1origin": {"number": 1001}},
+				"safe_l2": {"number": 5000, "l1origin": {"number": 1001}},
+				"finalized_l2": {"number": 5000, "l1origin": {"number": 1001}}
+			}`,
+			want: observer.OPSyncStatus{
+				L2Heads: map[string]uint64{
+					observer.OPUnsafeHead:    5000,
+					observer.OPSafeHead:      5000,
+					observer.OPFinalizedHead: 5000,
+				},
+				L1Head: 1000,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, server := newOPStackNode(t)
+			node.syncStatus = tt.syncStatus
+
+			p := newTestOPStackProfile(t, config.OPStack{RollupNodeURL: server.URL})
+			p.refresh(context.Background(), nil)
+
+			if p.syncStatus == nil {
+				t.Fatal("sync status is nil")
+			}
+
+			got := *p.syncStatus
+			if got.L1Head != tt.want.L1Head || got.L1OriginLag != tt.want.L1OriginLag {
+				t.Errorf("got L1 head %d and lag %d, want %d and %d", got.L1Head, got.L1OriginLag, tt.want.L1Head, tt.want.L1OriginLag)
+			}
+
+			for head, number := range tt.want.L2Heads {
+				if got.L2Heads[head] != number {
+					t.Errorf("got %s head %d, want %d", head, got.L2Heads[head], number)
+				}
+			}
+		})
+	}
+}
+
+func TestOPStackReusesClients(t *testing.T) {
+	node, server := newOPStackNode(t)
+	node.syncStatus = `{"head_l1": {"number": 1}}`
+	node.set("gameCount", big.NewInt(0))
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	p := newTestOPStackProfile(t, config.OPStack{
+		RollupNodeURL:             url,
+		L1URL:                     url,
+		DisputeGameFactoryAddress: ptr(common.Address{1}.Hex()),
+	})
+
+	for i := 0; i < 3; i++ {
+		p.refresh(context.Background(), nil)
+	}
+
+	// The L1 and rollup node clients are each dialed once.
+	if dials := node.dialed(); dials != 2 {
+		t.Errorf("got %d dials, want 2", dials)
+	}
+
+	for _, method := range []string{"optimism_syncStatus", "gameCount"} {
+		if calls := node.called(method); calls != 3 {
+			t.Errorf("got %d %s calls, want 3", calls, method)
+		}
+	}
+}
+
+func TestOPStackDisputeGameFactory(t *testing.T) {
+	node, server := newOPStackNode(t)
+
+	created := time.Unix(1700000000, 0)
+	node.set("gameCount", big.NewInt(5))
+	node.set("gameAtIndex", uint32(0), uint64(created.Unix()), common.Address{2})
+	node.set("latestOutputIndex", big.NewInt(100))
+
+	// The dispute game factory is preferred when both are configured.
+	p := newTestOPStackProfile(t, config.OPStack{
+		L1URL:                     server.URL,
+		DisputeGameFactoryAddress: ptr(common.Address{1}.Hex()),
+		L2OutputOracleAddress:     ptr(common.Address{3}.Hex()),
+	})
+
+	p.refresh(context.Background(), nil)
+
+	if p.proposals == nil {
+		t.Fatal("output proposals are nil")
+	}
+
+	if p.proposals.Latest != 4 || p.proposals.L2Block != nil || !p.proposals.Time.Equal(created) || p.proposals.Observed != 0 {
+		t.Errorf("unexpected output proposals %+v", *p.proposals)
+	}
+
+	if node.called("latestOutputIndex") != 0 {
+		t.Error("L2 output oracle was called")
+	}
+
+	// Without a proposer address, the proposer can't be derived from the
+	// dispute game factory.
+	if len(p.balances) != 0 {
+		t.Errorf("unexpected balances %+v", p.balances)
+	}
+
+	node.set("gameCount", big.NewInt(7))
+	p.refresh(context.Background(), nil)
+
+	if p.proposals == nil || p.proposals.Latest != 6 || p.proposals.Observed != 2 {
+		t.Errorf("unexpected output proposals %+v", p.proposals)
+	}
+}
+
+func TestOPStackL2OutputOracle(t *testing.T) {
+	node, server := newOPStackNode(t)
+
+	proposed := time.Unix(1700000000, 0)
+	proposer := common.Address{4}
+	batcher := common.Address{5}
+
+	node.set("latestOutputIndex", big.NewInt(3))
+	node.set("getL2Output", contracts.TypesOutputProposal{
+		OutputRoot:    [32]byte{6},
+		Timestamp:     big.NewInt(proposed.Unix()),
+		L2BlockNumber: big.NewInt(1200),
+	})
+	node.set("proposer", proposer)
+	node.balances[proposer] = big.NewInt(1e18)
+	node.balances[batcher] = big.NewInt(2e18)
+
+	p := newTestOPStackProfile(t, config.OPStack{
+		L1URL:                 server.URL,
+		L2OutputOracleAddress: ptr(common.Address{3}.Hex()),
+		BatcherAddress:        ptr(batcher.Hex()),
+	})
+
+	p.refresh(context.Background(), nil)
+
+	if p.proposals == nil {
+		t.Fatal("output proposals are nil")
+	}
+
+	if p.proposals.Latest != 3 || p.proposals.L2Block == nil || *p.proposals.L2Block != 1200 || !p.proposals.Time.Equal(proposed) {
+		t.Errorf("unexpected output proposals %+v", *p.proposals)
+	}
+
+	// The proposer defaults to the L2 output oracle's proposer.
+	want := map[string]observer.OPBalance{
+		observer.OPProposer: {Role: observer.OPProposer, Address: proposer, Balance: big.NewInt(1e18)},
+		observer.OPBatcher:  {Role: observer.OPBatcher, Address: batcher, Balance: big.NewInt(2e18)},
+	}
+
+	if len(p.balances) != len(want) {
+		t.Fatalf("got %d balances, want %d", len(p.balances), len(want))
+	}
+
+	for _, got := range p.balances {
+		w := want[got.Role]
+		if got.Address != w.Address || got.Balance.Cmp(w.Balance) != 0 {
+			t.Errorf("got %s balance %v of %v, want %v of %v", got.Role, got.Balance, got.Address, w.Balance, w.Address)
+		}
+	}
+}
+
+func TestOPStackProposerAddress(t *testing.T) {
+	node, server := newOPStackNode(t)
+
+	proposer := common.Address{7}
+	node.set("latestOutputIndex", big.NewInt(0))
+	node.set("getL2Output", contracts.TypesOutputProposal{
+		Timestamp:     big.NewInt(0),
+		L2BlockNumber: big.NewInt(0),
+	})
+	node.set("proposer", common.Address{4})
+	node.balances[proposer] = big.NewInt(1)
+
+	// A configured proposer address overrides the L2 output oracle's proposer.
+	p := newTestOPStackProfile(t, config.OPStack{
+		L1URL:                 server.URL,
+		L2OutputOracleAddress: ptr(common.Address{3}.Hex()),
+		ProposerAddress:       ptr(proposer.Hex()),
+	})
+
+	p.refresh(context.Background(), nil)
+
+	if node.called("proposer") != 0 {
+		t.Error("L2 output oracle's proposer was called")
+	}
+
+	if len(p.balances) != 1 || p.balances[0].Address != proposer {
+		t.Errorf("unexpected balances %+v", p.balances)
+	}
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/0xPolygon/panoptichain/config"
+)
+
+// chainProfile refreshes and publishes the state that's specific to a chain
+// profile. The RPC provider refreshes the profile after the state shared by
+// every EVM chain, and publishes it after its own events.
+type chainProfile interface {
+	refresh(context.Context, *ethclient.Client)
+	publish(context.Context)
+}
+
+// chainProfiles maps the chain profiles to their constructors. Networks without
+// a profile are monitored as generic EVM chains.
+var chainProfiles = map[string]func(*RPCProvider, RPCProviderOpts) chainProfile{
+	config.ProfilePolygonPoS:   newPolygonPoSProfile,
+	config.ProfilePolygonZkEVM: newPolygonZkEVMProfile,
+	config.ProfileOPStack:      newOPStackProfile,
+}
+
+// polygonPoSProfile refreshes the Bor validators and spans. Its state is
+// published along with the rest of the RPC provider's state.
+type polygonPoSProfile struct {
+	r *RPCProvider
+}
+
+func newPolygonPoSProfile(r *RPCProvider, opts RPCProviderOpts) chainProfile {
+	return &polygonPoSProfile{r: r}
+}
+
+func (p *polygonPoSProfile) refresh(ctx context.Context, c *ethclient.Client) {
+	p.r.refreshValidatorBalances(ctx, c)
+	p.r.refreshMissedBlockProposal(ctx, c)
+	p.r.refreshBorSpan()
+}
+
+func (p *polygonPoSProfile) publish(ctx context.Context) {}
+
+// polygonZkEVMProfile refreshes the trusted, virtual, and verified batches. Its
+// state is published along with the rest of the RPC provider's state.
+type polygonZkEVMProfile struct {
+	r *RPCProvider
+}
+
+func newPolygonZkEVMProfile(r *RPCProvider, opts RPCProviderOpts) chainProfile {
+	return &polygonZkEVMProfile{r: r}
+}
+
+func (p *polygonZkEVMProfile) refresh(ctx context.Context, c *ethclient.Client) {
+	p.r.refreshBatches(ctx, c)
+}
+
+func (p *polygonZkEVMProfile) publish(ctx context.Context) {}
@@ -4,6 +4,7 @@ package provider
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -36,6 +37,28 @@ type Provider interface {
 	PollingInterval() uint
 }
 
+// ProviderManager starts and stops providers while running. This is used by
+// providers that discover other networks to monitor, such as the trusted
+// sequencers of the rollup manager.
+type ProviderManager interface {
+	// Start runs the provider until it's stopped.
+	Start(Provider)
+
+	// Stop stops the provider and waits for it to return.
+	Stop(Provider)
+
+	// RemoveNetwork removes the network from the providers that keep state
+	// for other providers' networks, once it's no longer monitored.
+	RemoveNetwork(network.Network)
+}
+
+// NetworkRemover is implemented by meta-providers that keep state and series
+// for the networks of other providers. RemoveNetwork drops the network's state
+// and deletes its series before the next publish.
+type NetworkRemover interface {
+	RemoveNetwork(network.Network)
+}
+
 func timer(duration *time.Duration) func() {
 	start := time.Now()
 	return func() {
@@ -54,3 +77,10 @@ func NewLogger(n network.Network, provider string) zerolog.Logger {
 		Str("provider", provider).
 		Logger()
 }
+
+// withoutNetwork returns the messages that aren't for the network.
+func withoutNetwork(messages []*observer.CoreMessage, n network.Network) []*observer.CoreMessage {
+	return slices.DeleteFunc(slices.Clone(messages), func(m *observer.CoreMessage) bool {
+		return m.Network() != nil && m.Network().GetName() == n.GetName()
+	})
+}
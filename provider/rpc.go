@@ -3,7 +3,6 @@ package provider
 import (
 	"bytes"
 	"context"
-	"crypto/ecdsa"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -32,7 +31,6 @@ import (
 	"github.com/0xPolygon/panoptichain/network"
 	"github.com/0xPolygon/panoptichain/observer"
 	"github.com/0xPolygon/panoptichain/observer/topics"
-	"github.com/0xPolygon/panoptichain/util"
 )
 
 // RPCProvider is the generic struct for all EVM style JSON RPC services.
@@ -54,36 +52,99 @@ type RPCProvider struct {
 	timeToMine       *config.TimeToMine
 	accounts         []string
 	accountBalances  observer.AccountBalances
+	tokenBalances    []config.TokenBalance
+	tokenMetadata    map[common.Address]*tokenMetadata
+	erc20Balances    observer.ERC20Balances
+	exchangeRates    *ExchangeRatesProvider
 	timeToFinalized  *uint64
 	blockLookBack    uint64
 
+	// These are set when the meta-providers that consume the events collected
+	// by this provider are configured.
+	stateSyncEnabled            bool
+	bridgeReconciliationEnabled bool
+	exitRootConsistencyEnabled  bool
+	batchPipelineEnabled        bool
+
+	contractCalls       []*contractCall
+	contractCallResults []*observer.ContractCall
+	multicallAddress    *common.Address
+	multicallChecked    bool
+
+	ttm                 timeToMineState
+	gasPriceSuggestions []*gasPriceSuggestion
+	gasPriceOracle      []*observer.GasPriceOracle
+
 	// PoS
-	stateSync            map[bool]*observer.StateSync
-	checkpointSignatures map[bool]*observer.CheckpointSignatures
-	validatorBalances    observer.ValidatorWalletBalances
-	missedBlockProposal  observer.MissedBlockProposal
+	stateSync               map[bool]*observer.StateSync
+	stateSyncEvents         *observer.StateSyncEvents
+	checkpointSignatures    map[bool]*observer.CheckpointSignatures
+	checkpointNetwork       network.Network
+	checkpointParticipation []*observer.CheckpointParticipation
+	lastCheckpointID        *big.Int
+	validatorBalances       observer.ValidatorWalletBalances
+	missedBlockProposal     observer.MissedBlockProposal
+	borSpan                 *observer.BorSpan
 
 	// zkEVM
 	batches        observer.ZkEVMBatches
-	trustedBatches []*zkevmtypes.Batch
+	trustedBatches []*observer.TrustedBatch
+	chainID        *uint64
+
+	// openTrustedBatch is the first trusted batch that was still open when it
+	// was fetched, so it's fetched again until it's closed.
+	openTrustedBatch uint64
+
+	// rollupEconomics joins the rollup manager's transaction fees, rewards, and
+	// balances. The spends are the fees paid within the burn rate window.
+	rollupEconomics  *observer.RollupEconomics
+	sequencerSpends  map[uint32][]rollupSpend
+	aggregatorSpends map[common.Address][]rollupSpend
+	economicsStart   time.Time
+
+	// forcedBatches maps the rollup ID to its forced batches that haven't been
+	// sequenced.
+	forcedBatches map[uint32]*rollupForcedBatches
+
+	// These are only populated when the batch pipeline provider is configured.
+	trustedBatchTimes *observer.TrustedBatchTimes
+	rollupBatchStages observer.RollupBatchStages
 
 	globalExitRoot   *observer.ExitRoot
 	mainnetExitRoot  *observer.ExitRoot
 	rollupExitRoot   *observer.ExitRoot
 	rollupExitRootL2 *observer.ExitRoot
 
+	// These are only populated when the exit root consistency provider is
+	// configured.
+	l1GlobalExitRoots      observer.GlobalExitRoots
+	l2GlobalExitRoots      observer.GlobalExitRoots
+	exitRootConsistencies  observer.ExitRootConsistencies
+	pendingGlobalExitRoots pendingGlobalExitRoots
+
 	bridgeEvents []*contracts.PolygonZkEVMBridgeV2BridgeEvent
 	claimEvents  []*contracts.PolygonZkEVMBridgeV2ClaimEvent
 
 	bridgeEventTimes observer.BridgeEventTimes
+	bridgeNetworkID  *uint32
+	bridgeActivity   *observer.BridgeActivity
 	claimEventTimes  observer.ClaimEventTimes
 
+	bridgeTokens         []config.Token
+	bridgeTokenFlows     map[observer.BridgeToken]*observer.BridgeTokenFlow
+	wrappedTokens        map[observer.BridgeToken]wrappedToken
+	bridgeLockedBalances observer.BridgeLockedBalances
+
 	depositCount            *big.Int
 	lastUpdatedDepositCount *uint32
 
-	rollupManager       *observer.RollupManager
-	trustedSequencers   map[uint32]*RPCProvider
-	trustedSequencerURL chan string
+	// profile refreshes the state specific to the network's chain profile.
+	profile chainProfile
+
+	rollupManager     *observer.RollupManager
+	rollups           []config.Rollup
+	manager           ProviderManager
+	trustedSequencers map[uint32]*RPCProvider
 
 	// These contract addresses will be derived from the PolygonRollupManager
 	// contract.
@@ -102,6 +163,29 @@ type RPCProviderOpts struct {
 	TimeToMine    *config.TimeToMine
 	Accounts      []string
 	BlockLookBack uint64
+	Calls         []config.ContractCall
+	TokenBalances []config.TokenBalance
+	BridgeTokens  []config.Token
+	Rollups       []config.Rollup
+	ExchangeRates *ExchangeRatesProvider
+
+	// Manager runs the trusted sequencer providers of the rollup manager.
+	Manager ProviderManager
+
+	// CheckpointNetwork is the Polygon PoS network whose checkpoints are
+	// submitted to the checkpoint contract. If nil, it is derived from the
+	// network.
+	CheckpointNetwork network.Network
+
+	// OPStack configures the OP Stack profile.
+	OPStack *config.OPStack
+
+	// These enable collecting the events consumed by the meta-providers, and
+	// are set when the meta-provider is configured.
+	StateSync            bool
+	BridgeReconciliation bool
+	ExitRootConsistency  bool
+	BatchPipeline        bool
 }
 
 // NewRPCProvider creates a new RPC provider and configures it's event bus.
@@ -113,7 +197,18 @@ func NewRPCProvider(opts RPCProviderOpts) *RPCProvider {
 		logger.Error().Err(err).Msg("Failed to parse RPC URL")
 	}
 
-	return &RPCProvider{
+	var calls []*contractCall
+	for _, call := range opts.Calls {
+		cc, err := newContractCall(call)
+		if err != nil {
+			logger.Error().Err(err).Str("label", call.Label).Msg("Failed to parse contract call")
+			continue
+		}
+
+		calls = append(calls, cc)
+	}
+
+	r := &RPCProvider{
 		URL:                  opts.URL,
 		Label:                opts.Label,
 		parsedURL:            parsedURL,
@@ -134,10 +229,36 @@ func NewRPCProvider(opts RPCProviderOpts) *RPCProvider {
 		bridgeEventTimes:     make(observer.BridgeEventTimes),
 		claimEventTimes:      make(observer.ClaimEventTimes),
 		trustedSequencers:    make(map[uint32]*RPCProvider),
-		trustedSequencerURL:  make(chan string),
 		rollupContracts:      make(map[uint32]common.Address),
 		blockLookBack:        opts.BlockLookBack,
+		contractCalls:        calls,
+		tokenBalances:        opts.TokenBalances,
+		bridgeTokens:         opts.BridgeTokens,
+		rollups:              opts.Rollups,
+		manager:              opts.Manager,
+		tokenMetadata:        make(map[common.Address]*tokenMetadata),
+		wrappedTokens:        make(map[observer.BridgeToken]wrappedToken),
+		exchangeRates:        opts.ExchangeRates,
+		checkpointNetwork:    opts.CheckpointNetwork,
+
+		stateSyncEnabled:            opts.StateSync,
+		bridgeReconciliationEnabled: opts.BridgeReconciliation,
+		exitRootConsistencyEnabled:  opts.ExitRootConsistency,
+		batchPipelineEnabled:        opts.BatchPipeline,
+	}
+
+	if newProfile, ok := chainProfiles[opts.Network.GetProfile()]; ok {
+		r.profile = newProfile(r, opts)
+	}
+
+	if opts.ExitRootConsistency && opts.Contracts.GlobalExitRootL2Address != nil && opts.EventBus != nil {
+		r.pendingGlobalExitRoots.roots = make(map[common.Hash]l1GlobalExitRoot)
+
+		s := &l1GlobalExitRootSubscriber{provider: r}
+		s.Register(opts.EventBus)
 	}
+
+	return r
 }
 
 func (r *RPCProvider) SetEventBus(bus *observer.EventBus) {
@@ -160,22 +281,28 @@ func (r *RPCProvider) RefreshState(ctx context.Context) error {
 
 	r.refreshStateSync(ctx, c, true)
 	r.refreshStateSync(ctx, c, false)
+	r.refreshStateSyncEvents(ctx, c)
 	r.refreshCheckpoint(ctx, c)
 
-	if r.Network.IsPolygonPoS() {
-		r.refreshValidatorBalances(ctx, c)
-		r.refreshMissedBlockProposal(ctx, c)
-	}
-
 	r.refreshTxPoolStatus(ctx, c)
 	r.refreshTimeToMine(ctx, c)
+	r.refreshGasPriceOracle()
 	r.refreshAccountBalances(ctx, c)
+	r.refreshTokenBalances(ctx, c)
+	r.refreshContractCalls(ctx, c)
+
+	// The L2 global exit roots are collected from both the batches and the
+	// GlobalExitRootL2 contract.
+	r.l2GlobalExitRoots = nil
 
-	if r.Network.IsPolygonZkEVM() {
-		r.refreshBatches(ctx, c)
+	if r.profile != nil {
+		r.profile.refresh(ctx, c)
 	}
 
 	r.refreshRollupManager(ctx, c)
+
+	// Both the exit roots and the bridge check exit root consistency.
+	r.exitRootConsistencies = nil
 	r.refreshExitRoots(ctx, c)
 	r.refreshExitRootsL2(ctx, c)
 	r.refreshBridge(ctx, c)
@@ -215,15 +342,30 @@ func (r *RPCProvider) PublishEvents(ctx context.Context) error {
 		r.bus.Publish(ctx, topics.BorMissedBlockProposal, missedBlockProposal)
 	}
 
+	if r.borSpan != nil {
+		m := observer.NewMessage(r.Network, r.Label, r.borSpan)
+		r.bus.Publish(ctx, topics.BorSpan, m)
+	}
+
 	for _, stateSync := range r.stateSync {
 		r.bus.Publish(ctx, topics.BorStateSync, observer.NewMessage(r.Network, r.Label, stateSync))
 	}
 
+	if r.stateSyncEvents != nil {
+		m := observer.NewMessage(r.Network, r.Label, r.stateSyncEvents)
+		r.bus.Publish(ctx, topics.StateSyncEvents, m)
+	}
+
 	for _, checkpointSignatures := range r.checkpointSignatures {
 		m := observer.NewMessage(r.Network, r.Label, checkpointSignatures)
 		r.bus.Publish(ctx, topics.CheckpointSignatures, m)
 	}
 
+	for _, participation := range r.checkpointParticipation {
+		m := observer.NewMessage(r.Network, r.Label, participation)
+		r.bus.Publish(ctx, topics.CheckpointParticipation, m)
+	}
+
 	if len(r.validatorBalances) > 0 {
 		validatorWalletBalance := observer.NewMessage(r.Network, r.Label, r.validatorBalances)
 		r.bus.Publish(ctx, topics.ValidatorWallet, validatorWalletBalance)
@@ -254,6 +396,36 @@ func (r *RPCProvider) PublishEvents(ctx context.Context) error {
 		r.bus.Publish(ctx, topics.ExitRoots, observer.NewMessage(r.Network, r.Label, er))
 	}
 
+	if r.trustedBatchTimes != nil {
+		m := observer.NewMessage(r.Network, r.Label, r.trustedBatchTimes)
+		r.bus.Publish(ctx, topics.TrustedBatchTimes, m)
+	}
+
+	if r.rollupEconomics != nil {
+		m := observer.NewMessage(r.Network, r.Label, r.rollupEconomics)
+		r.bus.Publish(ctx, topics.RollupEconomics, m)
+	}
+
+	if len(r.rollupBatchStages) > 0 {
+		m := observer.NewMessage(r.Network, r.Label, r.rollupBatchStages)
+		r.bus.Publish(ctx, topics.RollupBatchStages, m)
+	}
+
+	if len(r.l1GlobalExitRoots) > 0 {
+		m := observer.NewMessage(r.Network, r.Label, r.l1GlobalExitRoots)
+		r.bus.Publish(ctx, topics.L1GlobalExitRoots, m)
+	}
+
+	if len(r.l2GlobalExitRoots) > 0 {
+		m := observer.NewMessage(r.Network, r.Label, r.l2GlobalExitRoots)
+		r.bus.Publish(ctx, topics.L2GlobalExitRoots, m)
+	}
+
+	if len(r.exitRootConsistencies) > 0 {
+		m := observer.NewMessage(r.Network, r.Label, r.exitRootConsistencies)
+		r.bus.Publish(ctx, topics.ExitRootConsistency, m)
+	}
+
 	if r.depositCount != nil || r.lastUpdatedDepositCount != nil {
 		m := observer.NewMessage(r.Network, r.Label, &observer.DepositCounts{
 			DepositCount:            r.depositCount,
@@ -270,6 +442,26 @@ func (r *RPCProvider) PublishEvents(ctx context.Context) error {
 		r.bus.Publish(ctx, topics.ClaimEvent, observer.NewMessage(r.Network, r.Label, claimEvent))
 	}
 
+	if r.bridgeActivity != nil {
+		m := observer.NewMessage(r.Network, r.Label, r.bridgeActivity)
+		r.bus.Publish(ctx, topics.BridgeActivity, m)
+	}
+
+	if len(r.bridgeTokenFlows) > 0 {
+		flows := make(observer.BridgeTokenFlows, 0, len(r.bridgeTokenFlows))
+		for _, flow := range r.bridgeTokenFlows {
+			flows = append(flows, flow)
+		}
+
+		m := observer.NewMessage(r.Network, r.Label, flows)
+		r.bus.Publish(ctx, topics.BridgeTokenFlows, m)
+	}
+
+	if len(r.bridgeLockedBalances) > 0 {
+		m := observer.NewMessage(r.Network, r.Label, r.bridgeLockedBalances)
+		r.bus.Publish(ctx, topics.BridgeLockedBalances, m)
+	}
+
 	if len(r.bridgeEventTimes) > 0 {
 		m := observer.NewMessage(r.Network, r.Label, r.bridgeEventTimes)
 		r.bus.Publish(ctx, topics.BridgeEventTimes, m)
@@ -290,6 +482,21 @@ func (r *RPCProvider) PublishEvents(ctx context.Context) error {
 		r.bus.Publish(ctx, topics.AccountBalances, m)
 	}
 
+	if len(r.erc20Balances) > 0 {
+		m := observer.NewMessage(r.Network, r.Label, r.erc20Balances)
+		r.bus.Publish(ctx, topics.ERC20Balances, m)
+	}
+
+	for _, call := range r.contractCallResults {
+		m := observer.NewMessage(r.Network, r.Label, call)
+		r.bus.Publish(ctx, topics.ContractCall, m)
+	}
+
+	for _, oracle := range r.gasPriceOracle {
+		m := observer.NewMessage(r.Network, r.Label, oracle)
+		r.bus.Publish(ctx, topics.GasPriceOracle, m)
+	}
+
 	for _, batch := range r.trustedBatches {
 		m := observer.NewMessage(r.Network, r.Label, batch)
 		r.bus.Publish(ctx, topics.TrustedBatch, m)
@@ -305,6 +512,10 @@ func (r *RPCProvider) PublishEvents(ctx context.Context) error {
 		r.bus.Publish(ctx, topics.FinalizedHeight, m)
 	}
 
+	if r.profile != nil {
+		r.profile.publish(ctx)
+	}
+
 	r.bus.Publish(ctx, topics.RefreshStateTime, observer.NewMessage(r.Network, r.Label, r.refreshStateTime))
 
 	return nil
@@ -366,6 +577,13 @@ func (r *RPCProvider) getFilterOpts() *bind.FilterOpts {
 	return &opts
 }
 
+// isScanned returns true if the block was already scanned by the previous
+// refresh. The filter range starts at the previous block number, so events in
+// that block are filtered again.
+func (r *RPCProvider) isScanned(block uint64) bool {
+	return r.prevBlockNumber > 0 && block <= r.prevBlockNumber
+}
+
 // cast call --rpc-url https://eth.llamarpc.com 0x28e4F3a7f651294B9564800b2D01f35189A5bFbE 'function counter() view returns(uint256)'
 // cast call --rpc-url https://polygon-rpc.com 0x0000000000000000000000000000000000001001 'function lastStateId() view returns(uint256)'
 func (r *RPCProvider) refreshStateSync(ctx context.Context, c *ethclient.Client, finalized bool) error {
@@ -422,7 +640,106 @@ func (r *RPCProvider) refreshStateSync(ctx context.Context, c *ethclient.Client,
 	return nil
 }
 
+// refreshStateSyncEvents collects the state syncs sent by the StateSender on L1
+// or committed by the StateReceiver on Bor within the block range, so the
+// state sync provider can correlate them by state ID.
+func (r *RPCProvider) refreshStateSyncEvents(ctx context.Context, c *ethclient.Client) error {
+	r.stateSyncEvents = nil
+
+	if !r.stateSyncEnabled {
+		return nil
+	}
+
+	events := &observer.StateSyncEvents{
+		Sent:      make(map[uint64]time.Time),
+		Committed: make(map[uint64]time.Time),
+	}
+
+	latest := r.stateSync[false]
+
+	if r.contracts.StateSyncSenderAddress != nil {
+		events.Network = r.getCheckpointNetwork()
+		if events.Network == nil {
+			return nil
+		}
+
+		if latest != nil {
+			events.LatestSentID = &latest.ID
+		}
+
+		address := common.HexToAddress(*r.contracts.StateSyncSenderAddress)
+		ss, err := contracts.NewStateSender(address, c)
+		if err != nil {
+			r.logger.Error().Err(err).Msg("Failed to bind state sender contract")
+			return err
+		}
+
+		iter, err := ss.FilterStateSynced(r.getFilterOpts(), nil, nil, nil)
+		if err != nil {
+			r.logger.Warn().Err(err).Msg("Failed to filter StateSynced events")
+			return err
+		}
+
+		for iter.Next() {
+			if t, ok := r.getBlockTime(ctx, c, iter.Event.Raw.BlockNumber); ok {
+				events.Sent[iter.Event.Id.Uint64()] = t
+			}
+		}
+	} else if r.contracts.StateSyncReceiverAddress != nil {
+		events.Network = r.Network
+
+		if latest != nil {
+			events.LatestCommittedID = &latest.ID
+		}
+
+		address := common.HexToAddress(*r.contracts.StateSyncReceiverAddress)
+		sr, err := contracts.NewStateReceiver(address, c)
+		if err != nil {
+			r.logger.Error().Err(err).Msg("Failed to bind state receiver contract")
+			return err
+		}
+
+		iter, err := sr.FilterStateCommitted(r.getFilterOpts(), nil)
+		if err != nil {
+			r.logger.Warn().Err(err).Msg("Failed to filter StateCommitted events")
+			return err
+		}
+
+		for iter.Next() {
+			if t, ok := r.getBlockTime(ctx, c, iter.Event.Raw.BlockNumber); ok {
+				events.Committed[iter.Event.StateId.Uint64()] = t
+			}
+		}
+	} else {
+		return nil
+	}
+
+	r.stateSyncEvents = events
+
+	return nil
+}
+
+// getBlockTime returns the timestamp of the block, preferring the block buffer.
+// It returns false if the block can't be fetched.
+func (r *RPCProvider) getBlockTime(ctx context.Context, c *ethclient.Client, number uint64) (time.Time, bool) {
+	if b, err := r.blockBuffer.GetBlock(number); err == nil {
+		if block, ok := b.(*types.Block); ok {
+			return time.Unix(int64(block.Time()), 0), true
+		}
+	}
+
+	header, err := c.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		r.logger.Warn().Err(err).Uint64("block_number", number).Msg("Failed to get block header")
+		return time.Time{}, false
+	}
+
+	return time.Unix(int64(header.Time), 0), true
+}
+
 func (r *RPCProvider) refreshCheckpoint(ctx context.Context, c *ethclient.Client) {
+	r.checkpointParticipation = nil
+
 	if r.contracts.CheckpointAddress == nil {
 		return
 	}
@@ -440,47 +757,88 @@ func (r *RPCProvider) refreshCheckpoint(ctx context.Context, c *ethclient.Client
 		return
 	}
 
-	// Get the last NewHeaderBlock event.
-	var event *contracts.RootChainNewHeaderBlock
+	var events []*contracts.RootChainNewHeaderBlock
 	for iter.Next() && iter.Event != nil {
-		event = iter.Event
+		events = append(events, iter.Event)
 	}
 
-	if event == nil {
+	if len(events) == 0 {
 		r.logger.Error().Msg("NewHeaderBlock event is nil")
 		return
 	}
 
-	// Grab that block so that we know when the transaction was mined.
-	block, err := c.BlockByHash(ctx, event.Raw.BlockHash)
-	if err != nil {
-		r.logger.Error().Err(err).Msg("Failed to get block by hash")
-		return
+	// Every new checkpoint in the window is compared against the validator set,
+	// but only the last one is used for the latest checkpoint metrics.
+	for i, event := range events {
+		latest := i == len(events)-1
+		isNew := r.lastCheckpointID == nil || event.HeaderBlockId.Cmp(r.lastCheckpointID) > 0
+		if !latest && !isNew {
+			continue
+		}
+
+		r.logger.Trace().Any("event", event).Str("network", r.Network.GetName()).Msg("NewHeaderBlock event")
+
+		// Grab that block so that we know when the transaction was mined.
+		block, err := c.BlockByHash(ctx, event.Raw.BlockHash)
+		if err != nil {
+			r.logger.Error().Err(err).Msg("Failed to get block by hash")
+			continue
+		}
+
+		signers, err := r.getCheckpointSigners(ctx, c, event)
+		if err != nil {
+			continue
+		}
+
+		if isNew {
+			r.lastCheckpointID = event.HeaderBlockId
+			r.refreshCheckpointParticipation(event, signers)
+		}
+
+		if !latest {
+			continue
+		}
+
+		r.refreshFinalizedCheckpoint(ctx, c)
+
+		finalized := false
+		cs := r.checkpointSignatures[finalized]
+		seen := cs != nil && event.HeaderBlockId.Cmp(cs.Event.HeaderBlockId) == 0
+		r.checkpointSignatures[finalized] = &observer.CheckpointSignatures{
+			Event:     event,
+			Block:     block,
+			Signers:   signers,
+			Seen:      seen,
+			Finalized: finalized,
+		}
 	}
+}
 
-	r.logger.Trace().Any("event", event).Str("network", r.Network.GetName()).Msg("Latest NewHeaderBlock event")
+// getCheckpointSigners recovers the addresses of the validators that signed the
+// checkpoint from the submitCheckpoint transaction.
+func (r *RPCProvider) getCheckpointSigners(ctx context.Context, c *ethclient.Client, event *contracts.RootChainNewHeaderBlock) ([]common.Address, error) {
 	tx, _, err := c.TransactionByHash(ctx, event.Raw.TxHash)
 	if err != nil {
 		r.logger.Error().Err(err).Msg("Could not find submitCheckpoint transaction")
-		return
+		return nil, err
 	}
 
 	abi, err := contracts.RootChainMetaData.GetAbi()
 	if err != nil {
 		r.logger.Error().Err(err).Msg("Failed to get root chain ABI")
-		return
+		return nil, err
 	}
 
 	method, err := abi.MethodById(tx.Data()[:4])
 	if err != nil {
 		r.logger.Error().Err(err).Msg("Contract method not found")
-		return
+		return nil, err
 	}
 
 	inputs := make(map[string]any)
 	if err := method.Inputs.UnpackIntoMap(inputs, tx.Data()[4:]); err != nil {
 		r.logger.Error().Err(err).Msg("Failed to unpack input params")
-		return
+		return nil, err
 	}
 
 	data := inputs["data"].([]byte)
@@ -505,18 +863,78 @@ func (r *RPCProvider) refreshCheckpoint(ctx context.Context, c *ethclient.Client
 		signers = append(signers, address)
 	}
 
-	r.refreshFinalizedCheckpoint(ctx, c)
+	return signers, nil
+}
 
-	finalized := false
-	cs := r.checkpointSignatures[finalized]
-	seen := cs != nil && event.HeaderBlockId.Cmp(cs.Event.HeaderBlockId) == 0
-	r.checkpointSignatures[finalized] = &observer.CheckpointSignatures{
-		Event:     event,
-		Block:     block,
-		Signers:   signers,
-		Seen:      seen,
-		Finalized: finalized,
+// refreshCheckpointParticipation compares the checkpoint signers with the active
+// validator set of the Polygon PoS network the checkpoint belongs to. The
+// current validator set is used, so validator set changes between the
+// checkpoint and now are not accounted for.
+func (r *RPCProvider) refreshCheckpointParticipation(event *contracts.RootChainNewHeaderBlock, signers []common.Address) {
+	n := r.getCheckpointNetwork()
+	if n == nil {
+		return
+	}
+
+	validators, err := api.Validators(n)
+	if err != nil {
+		r.logger.Warn().Err(err).Msg("Failed to get validators for checkpoint participation")
+		return
+	}
+
+	signed := make(map[common.Address]struct{}, len(signers))
+	for _, signer := range signers {
+		signed[signer] = struct{}{}
+	}
+
+	participation := &observer.CheckpointParticipation{
+		HeaderBlockID: event.HeaderBlockId,
+	}
+
+	for _, validator := range validators {
+		if validator.IsJailed() || validator.GetPower() == 0 {
+			continue
+		}
+
+		participation.TotalPower += validator.GetPower()
+
+		if _, ok := signed[common.HexToAddress(validator.GetSigner())]; ok {
+			participation.SignedPower += validator.GetPower()
+			continue
+		}
+
+		participation.NonSigners = append(participation.NonSigners, validator)
+	}
+
+	r.checkpointParticipation = append(r.checkpointParticipation, participation)
+}
+
+// getCheckpointNetwork returns the Polygon PoS network whose checkpoints are
+// submitted on this network.
+func (r *RPCProvider) getCheckpointNetwork() network.Network {
+	if r.checkpointNetwork != nil {
+		return r.checkpointNetwork
+	}
+
+	var name string
+	switch r.Network.GetName() {
+	case network.EthereumName:
+		name = network.PolygonMainnetName
+	case network.SepoliaName:
+		name = network.PolygonAmoyName
+	case network.GoerliName:
+		name = network.PolygonMumbaiName
+	default:
+		return nil
+	}
+
+	n, err := network.GetNetworkByName(name)
+	if err != nil {
+		r.logger.Warn().Err(err).Msg("Failed to get checkpoint network")
+		return nil
 	}
+
+	return n
 }
 
 func (r *RPCProvider) refreshFinalizedCheckpoint(ctx context.Context, c *ethclient.Client) {
@@ -804,6 +1222,68 @@ func (r *RPCProvider) refreshMissedBlockProposal(ctx context.Context, c *ethclie
 	return nil
 }
 
+// refreshBorSpan checks whether the new blocks were authored by the selected
+// producers of the span containing them. The spans are stored by the Heimdall
+// provider of the same network.
+func (r *RPCProvider) refreshBorSpan() {
+	r.borSpan = nil
+
+	latest, ok := api.GetSpan(r.Network, r.BlockNumber)
+	if !ok {
+		r.logger.Debug().Uint64("block_number", r.BlockNumber).Msg("No span found for block")
+		return
+	}
+
+	borSpan := &observer.BorSpan{
+		ID:              latest.ID,
+		BlocksRemaining: latest.EndBlock - r.BlockNumber,
+	}
+
+	start := r.prevBlockNumber + 1
+	if r.prevBlockNumber == 0 {
+		start = r.BlockNumber
+	}
+
+	for i := start; i <= r.BlockNumber; i++ {
+		span, ok := api.GetSpan(r.Network, i)
+		if !ok {
+			continue
+		}
+
+		b, err := r.blockBuffer.GetBlock(i)
+		if err != nil {
+			continue
+		}
+		block := b.(*types.Block)
+
+		bytes, err := api.Ecrecover(block.Header())
+		if err != nil {
+			r.logger.Warn().Err(err).Msg("Failed to get block signer")
+			continue
+		}
+
+		signer := "0x" + hex.EncodeToString(bytes)
+		inSpan := span.HasProducer(signer)
+
+		if i == r.BlockNumber {
+			borSpan.Author = signer
+			borSpan.AuthorInSpan = inSpan
+		}
+
+		if !inSpan {
+			r.logger.Warn().
+				Uint64("block_number", i).
+				Uint64("span_id", span.ID).
+				Str("signer", signer).
+				Msg("Block authored by a producer outside of the span")
+
+			borSpan.OutOfSpanSigners = append(borSpan.OutOfSpanSigners, signer)
+		}
+	}
+
+	r.borSpan = borSpan
+}
+
 type TxPoolStatus struct {
 	Pending string `json:"pending"`
 	Queued  string `json:"queued"`
@@ -834,126 +1314,133 @@ func (r *RPCProvider) refreshTxPoolStatus(ctx context.Context, c *ethclient.Clie
 	return nil
 }
 
-// refreshTimeToMine sends a transaction to the network and records the time it
-// took to be included in a block.
-func (r *RPCProvider) refreshTimeToMine(ctx context.Context, c *ethclient.Client) error {
-	if r.timeToMine == nil {
-		return nil
-	}
+func (r *RPCProvider) refreshAccountBalances(ctx context.Context, c *ethclient.Client) {
+	co := &bind.CallOpts{Context: ctx}
 
-	gasPriceFactor := r.timeToMine.GasPriceFactor
-	if gasPriceFactor == 0 {
-		gasPriceFactor = 1
-	}
+	for _, account := range r.accounts {
+		address := common.HexToAddress(account)
+		balances, ok := r.accountBalances[address]
+		if !ok {
+			balances = &observer.TokenBalances{}
+			r.accountBalances[address] = balances
+		}
 
-	sender := common.HexToAddress(r.timeToMine.Sender)
-	receiver := common.HexToAddress(r.timeToMine.Receiver)
-	privateKey, err := crypto.HexToECDSA(r.timeToMine.SenderPrivateKey)
-	if err != nil {
-		r.logger.Error().Err(err).Msg("Failed to parse SenderPrivateKey")
-		return err
-	}
+		eth, err := c.BalanceAt(ctx, address, nil)
+		if err != nil || eth == nil {
+			r.logger.Error().Err(err).
+				Any("address", address).
+				Str("token", observer.ETH).
+				Msg("Failed to get balance")
+		} else {
+			balances.ETH = eth
+		}
 
-	publicKey, ok := privateKey.Public().(*ecdsa.PublicKey)
-	if !ok {
-		err = errors.New("PublicKey type assertion failed")
-		r.logger.Error().Err(err).Send()
-		return err
+		balances.POL = r.getPOL(c, address, co, balances.POL)
 	}
+}
 
-	address := crypto.PubkeyToAddress(*publicKey)
-	if address != sender {
-		err = fmt.Errorf("sender address mismatch %v != %v", sender, address)
-		r.logger.Error().Err(err).Send()
-		return err
-	}
+// tokenMetadata is the ERC20 token metadata that only needs to be fetched once.
+type tokenMetadata struct {
+	symbol   string
+	decimals uint8
+}
 
-	gasPrice, err := c.SuggestGasPrice(ctx)
-	if err != nil {
-		r.logger.Error().Err(err).Msg("Failed to get suggested gas price")
-		return err
+// value returns the amount with the token decimals applied.
+func (t *tokenMetadata) value(amount *big.Int) *big.Float {
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(t.decimals)), nil)
+	return new(big.Float).Quo(new(big.Float).SetInt(amount), new(big.Float).SetInt(divisor))
+}
+
+// usd returns the USD value of the token units, or nil if there is no exchange
+// rate for the token symbol.
+func (r *RPCProvider) usd(symbol string, value *big.Float) *float64 {
+	if r.exchangeRates == nil {
+		return nil
 	}
-	gasPrice.Mul(gasPrice, big.NewInt(r.timeToMine.GasPriceFactor))
 
-	nonce, err := c.PendingNonceAt(ctx, sender)
-	if err != nil {
-		r.logger.Error().Err(err).Msg("Failed to get pending nonce")
-		return err
+	rate, ok := r.exchangeRates.Rate(symbol, "usd")
+	if !ok {
+		return nil
 	}
 
-	value := big.NewInt(r.timeToMine.Value)
-	gasLimit := r.timeToMine.GasLimit
-	data := []byte(r.timeToMine.Data)
+	usd, _ := new(big.Float).Mul(value, big.NewFloat(rate)).Float64()
+	return &usd
+}
 
-	tx := types.NewTransaction(nonce, receiver, value, gasLimit, gasPrice, data)
+// getTokenMetadata returns the cached token metadata, fetching it from the
+// contract if it hasn't been fetched yet.
+func (r *RPCProvider) getTokenMetadata(erc20 *contracts.ERC20, token config.Token, co *bind.CallOpts) (*tokenMetadata, error) {
+	address := common.HexToAddress(token.Address)
+	if metadata, ok := r.tokenMetadata[address]; ok {
+		return metadata, nil
+	}
 
-	chainID, err := c.ChainID(ctx)
+	decimals, err := erc20.Decimals(co)
 	if err != nil {
-		r.logger.Error().Err(err).Msg("Failed to get network ID")
-		return err
+		return nil, err
 	}
 
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), privateKey)
-	if err != nil {
-		r.logger.Error().Err(err).Msg("Failed to sign transaction")
-		return err
+	symbol := token.Symbol
+	if len(symbol) == 0 {
+		symbol, err = erc20.Symbol(co)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	err = c.SendTransaction(ctx, signedTx)
-	if err != nil {
-		r.logger.Error().Err(err).Msg("Failed to send transaction")
-		return err
+	metadata := &tokenMetadata{
+		symbol:   strings.ToLower(symbol),
+		decimals: decimals,
 	}
+	r.tokenMetadata[address] = metadata
 
-	start := time.Now()
+	return metadata, nil
+}
 
-	// Generally, all messages sent to topics should be done in the PublishEvents
-	// method. This is the exception because of its asynchronous nature. This
-	// implementation reduces complexity by not needing to manage shared variables.
-	go func() {
-		ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
-		defer cancel()
+// refreshTokenBalances fetches the ERC20 token balances of the configured
+// accounts. If an exchange rate for the token symbol is available, the USD
+// value is also computed.
+func (r *RPCProvider) refreshTokenBalances(ctx context.Context, c *ethclient.Client) {
+	r.erc20Balances = nil
+	co := &bind.CallOpts{Context: ctx}
 
-		_, err := bind.WaitMined(ctx, c, signedTx)
-		if err != nil {
-			r.logger.Error().Err(err).Msg("Failed to wait for transaction")
-		}
+	for _, tb := range r.tokenBalances {
+		account := common.HexToAddress(tb.Account)
 
-		ttm := &observer.TimeToMine{
-			Seconds:        time.Since(start).Seconds(),
-			GasPrice:       gasPrice,
-			GasPriceFactor: gasPriceFactor,
-		}
+		for _, token := range tb.Tokens {
+			address := common.HexToAddress(token.Address)
+
+			erc20, err := contracts.NewERC20(address, c)
+			if err != nil {
+				r.logger.Error().Err(err).Any("address", account).Any("token", address).Msg("Failed to bind ERC20 contract")
+				continue
+			}
 
-		m := observer.NewMessage(r.Network, r.Label, ttm)
-		r.bus.Publish(ctx, topics.TimeToMine, m)
-	}()
+			metadata, err := r.getTokenMetadata(erc20, token, co)
+			if err != nil {
+				r.logger.Error().Err(err).Any("address", account).Any("token", address).Msg("Failed to get ERC20 token metadata")
+				continue
+			}
 
-	return nil
-}
+			balance, err := erc20.BalanceOf(co, account)
+			if err != nil || balance == nil {
+				r.logger.Error().Err(err).Any("address", account).Any("token", address).Msg("Failed to get balance")
+				continue
+			}
 
-func (r *RPCProvider) refreshAccountBalances(ctx context.Context, c *ethclient.Client) {
-	co := &bind.CallOpts{Context: ctx}
+			value := metadata.value(balance)
 
-	for _, account := range r.accounts {
-		address := common.HexToAddress(account)
-		balances, ok := r.accountBalances[address]
-		if !ok {
-			balances = &observer.TokenBalances{}
-			r.accountBalances[address] = balances
-		}
+			b := &observer.ERC20Balance{
+				Account:             account,
+				Token:               address,
+				Symbol:              metadata.symbol,
+				Balance:             value,
+				USD:                 r.usd(metadata.symbol, value),
+				LowBalanceThreshold: token.LowBalanceThreshold,
+			}
 
-		eth, err := c.BalanceAt(ctx, address, nil)
-		if err != nil || eth == nil {
-			r.logger.Error().Err(err).
-				Any("address", address).
-				Str("token", observer.ETH).
-				Msg("Failed to get balance")
-		} else {
-			balances.ETH = eth
+			r.erc20Balances = append(r.erc20Balances, b)
 		}
-
-		balances.POL = r.getPOL(c, address, co, balances.POL)
 	}
 }
 
@@ -961,9 +1448,15 @@ func (r *RPCProvider) refreshBatches(ctx context.Context, c *ethclient.Client) {
 	r.trustedBatches = nil
 	prev := r.batches.TrustedBatch.Number
 
+	start := prev + 1
+	if r.openTrustedBatch > 0 && r.openTrustedBatch < start {
+		start = r.openTrustedBatch
+	}
+	r.openTrustedBatch = 0
+
 	r.refreshBatch(ctx, c, "zkevm_batchNumber", &r.batches.TrustedBatch)
-	for i := prev + 1; i <= r.batches.TrustedBatch.Number && prev != 0; i++ {
-		var batch zkevmtypes.Batch
+	for i := start; i <= r.batches.TrustedBatch.Number && prev != 0; i++ {
+		var batch trustedBatch
 
 		err := c.Client().CallContext(ctx, &batch, "zkevm_getBatchByNumber", i)
 		if err != nil {
@@ -971,11 +1464,97 @@ func (r *RPCProvider) refreshBatches(ctx context.Context, c *ethclient.Client) {
 			continue
 		}
 
-		r.trustedBatches = append(r.trustedBatches, &batch)
+		if batch.Closed {
+			r.trustedBatches = append(r.trustedBatches, r.getTrustedBatch(ctx, c, &batch))
+		} else if r.openTrustedBatch == 0 {
+			r.openTrustedBatch = i
+		}
+
+		// Batches that don't update the global exit root have a zero hash.
+		if r.exitRootConsistencyEnabled && batch.GlobalExitRoot != (common.Hash{}) {
+			r.l2GlobalExitRoots = append(r.l2GlobalExitRoots, observer.ExitRoot{
+				Hash: batch.GlobalExitRoot,
+				Time: time.Unix(int64(batch.Timestamp), 0),
+			})
+		}
 	}
 
 	r.refreshBatch(ctx, c, "zkevm_virtualBatchNumber", &r.batches.VirtualBatch)
 	r.refreshBatch(ctx, c, "zkevm_verifiedBatchNumber", &r.batches.VerifiedBatch)
+
+	r.trustedBatchTimes = nil
+	if r.batchPipelineEnabled {
+		r.refreshTrustedBatchTimes(ctx, c, prev)
+	}
+}
+
+// refreshTrustedBatchTimes records when the new trusted batches were first
+// seen, keyed by the chain ID so they can be matched with the rollup manager's
+// rollups.
+func (r *RPCProvider) refreshTrustedBatchTimes(ctx context.Context, c *ethclient.Client, prev uint64) {
+	if r.chainID == nil {
+		chainID, err := c.ChainID(ctx)
+		if err != nil {
+			r.logger.Error().Err(err).Msg("Failed to get chain ID")
+			return
+		}
+
+		id := chainID.Uint64()
+		r.chainID = &id
+	}
+
+	times := &observer.TrustedBatchTimes{
+		ChainID: *r.chainID,
+		Last:    r.batches.TrustedBatch.Number,
+		Times:   make(map[uint64]time.Time),
+	}
+
+	// The batches before the first refresh weren't seen as they were trusted.
+	for i := prev + 1; i <= r.batches.TrustedBatch.Number && prev != 0; i++ {
+		times.Times[i] = r.batches.TrustedBatch.Time
+	}
+
+	r.trustedBatchTimes = times
+}
+
+// trustedBatch is a trusted batch along with the closing reason that some
+// sequencer RPCs return.
+type trustedBatch struct {
+	zkevmtypes.Batch
+	ClosingReason string `json:"closingReason,omitempty"`
+}
+
+// getTrustedBatch returns the closed trusted batch with the time it was open,
+// which is from the batch timestamp to the last L2 block's timestamp.
+func (r *RPCProvider) getTrustedBatch(ctx context.Context, c *ethclient.Client, batch *trustedBatch) *observer.TrustedBatch {
+	tb := &observer.TrustedBatch{
+		Batch:         &batch.Batch,
+		ClosingReason: batch.ClosingReason,
+	}
+
+	if len(batch.Blocks) == 0 {
+		return tb
+	}
+
+	var closed uint64
+	switch last := batch.Blocks[len(batch.Blocks)-1]; {
+	case last.Block != nil:
+		closed = uint64(last.Block.Timestamp)
+	case last.Hash != nil:
+		header, err := c.HeaderByHash(ctx, *last.Hash)
+		if err != nil {
+			r.logger.Warn().Err(err).Msg("Failed to get last trusted batch block")
+			return tb
+		}
+		closed = header.Time
+	}
+
+	if closed >= uint64(batch.Timestamp) {
+		d := time.Duration(closed-uint64(batch.Timestamp)) * time.Second
+		tb.OpenToClose = &d
+	}
+
+	return tb
 }
 
 func (r *RPCProvider) refreshBatch(ctx context.Context, c *ethclient.Client, endpoint string, batch *observer.ZkEVMBatch) {
@@ -1044,9 +1623,199 @@ func (r *RPCProvider) refreshExitRoots(ctx context.Context, c *ethclient.Client)
 		r.rollupExitRoot = refreshExitRoot(r.rollupExitRoot, rollupExitRoot, time.Now())
 	}
 
+	r.l1GlobalExitRoots = nil
+	if r.exitRootConsistencyEnabled {
+		r.refreshL1GlobalExitRoots(ctx, c, contract)
+		r.checkRollupExitRoot(ctx, c, contract)
+	}
+
 	return nil
 }
 
+// refreshL1GlobalExitRoots collects the global exit roots from the L1 info tree
+// updates, so that every global exit root is checked on L2 and not only the
+// latest.
+func (r *RPCProvider) refreshL1GlobalExitRoots(ctx context.Context, c *ethclient.Client, contract *contracts.PolygonZkEVMGlobalExitRootV2) {
+	iter, err := contract.FilterUpdateL1InfoTree(r.getFilterOpts(), nil, nil)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to filter L1 info tree updates")
+		return
+	}
+
+	// The L1 info tree can be updated multiple times in a block.
+	headers := make(map[common.Hash]*types.Header)
+
+	for iter.Next() && iter.Event != nil {
+		event := iter.Event
+
+		header, ok := headers[event.Raw.BlockHash]
+		if !ok {
+			header, err = c.HeaderByHash(ctx, event.Raw.BlockHash)
+			if err != nil || header == nil {
+				r.logger.Error().Err(err).Msg("Failed to get block header")
+				continue
+			}
+			headers[event.Raw.BlockHash] = header
+		}
+
+		r.l1GlobalExitRoots = append(r.l1GlobalExitRoots, observer.ExitRoot{
+			Hash: crypto.Keccak256Hash(event.MainnetExitRoot[:], event.RollupExitRoot[:]),
+			Time: time.Unix(int64(header.Time), 0),
+		})
+	}
+
+	// The latest global exit root is also included when there are no logs in
+	// the block range.
+	if r.globalExitRoot != nil {
+		r.l1GlobalExitRoots = append(r.l1GlobalExitRoots, *r.globalExitRoot)
+	}
+}
+
+// getPinnedCallOpts returns call options at the provider's latest block, so
+// that multiple contract calls read the same state.
+func (r *RPCProvider) getPinnedCallOpts(ctx context.Context) *bind.CallOpts {
+	co := &bind.CallOpts{Context: ctx}
+	if r.BlockNumber > 0 {
+		co.BlockNumber = new(big.Int).SetUint64(r.BlockNumber)
+	}
+
+	return co
+}
+
+// checkRollupExitRoot checks that the rollup exit root of the global exit root
+// manager matches the rollup manager's rollup exit root.
+func (r *RPCProvider) checkRollupExitRoot(ctx context.Context, c *ethclient.Client, contract *contracts.PolygonZkEVMGlobalExitRootV2) {
+	if r.contracts.RollupManagerAddress == nil {
+		return
+	}
+
+	address := common.HexToAddress(*r.contracts.RollupManagerAddress)
+	rollupManager, err := contracts.NewPolygonRollupManager(address, c)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to bind rollup manager contract")
+		return
+	}
+
+	co := r.getPinnedCallOpts(ctx)
+
+	expected, err := rollupManager.GetRollupExitRoot(co)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to get rollup manager rollup exit root")
+		return
+	}
+
+	actual, err := contract.LastRollupExitRoot(co)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to get last rollup exit root")
+		return
+	}
+
+	r.addExitRootConsistency(observer.RollupExitRoot, expected, actual, nil)
+}
+
+// checkLocalExitRoot checks that the bridge's local exit root was pushed to the
+// global exit root manager. On L1 this is the mainnet exit root, and on L2 it
+// is the rollup exit root of the GlobalExitRootL2 contract. The roots can only
+// be compared when every deposit has been included in the last update.
+func (r *RPCProvider) checkLocalExitRoot(ctx context.Context, c *ethclient.Client, bridge *contracts.PolygonZkEVMBridgeV2) {
+	co := r.getPinnedCallOpts(ctx)
+
+	id := r.getBridgeNetworkID(bridge, &bind.CallOpts{Context: ctx})
+	if id == nil {
+		return
+	}
+
+	address, err := bridge.GlobalExitRootManager(co)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to get global exit root manager")
+		return
+	}
+
+	dc, err := bridge.DepositCount(co)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to get deposit count")
+		return
+	}
+
+	ludc, err := bridge.LastUpdatedDepositCount(co)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to get last updated deposit count")
+		return
+	}
+
+	expected, err := bridge.GetRoot(co)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to get bridge local exit root")
+		return
+	}
+
+	var root string
+	var actual [32]byte
+	if *id == 0 {
+		root = observer.MainnetExitRoot
+
+		manager, err := contracts.NewPolygonZkEVMGlobalExitRootV2(address, c)
+		if err != nil {
+			r.logger.Error().Err(err).Msg("Failed to bind global exit root contract")
+			return
+		}
+
+		actual, err = manager.LastMainnetExitRoot(co)
+		if err != nil {
+			r.logger.Error().Err(err).Msg("Failed to get last mainnet exit root")
+			return
+		}
+	} else {
+		root = observer.LocalExitRoot
+
+		manager, err := contracts.NewPolygonZkEVMGlobalExitRootL2(address, c)
+		if err != nil {
+			r.logger.Error().Err(err).Msg("Failed to bind global exit root l2 contract")
+			return
+		}
+
+		actual, err = manager.LastRollupExitRoot(co)
+		if err != nil {
+			r.logger.Error().Err(err).Msg("Failed to get last rollup exit root")
+			return
+		}
+	}
+
+	var pending uint32
+	if count := uint32(dc.Uint64()); count > ludc {
+		pending = count - ludc
+	}
+
+	// The bridge's root includes deposits that haven't been pushed yet, so the
+	// roots can't be compared.
+	if pending > 0 {
+		r.exitRootConsistencies = append(r.exitRootConsistencies, observer.ExitRootConsistency{
+			Root:            root,
+			PendingDeposits: &pending,
+		})
+		return
+	}
+
+	r.addExitRootConsistency(root, expected, actual, &pending)
+}
+
+func (r *RPCProvider) addExitRootConsistency(root string, expected, actual [32]byte, pending *uint32) {
+	consistent := expected == actual
+	if !consistent {
+		r.logger.Warn().
+			Str("root", root).
+			Str("expected", common.Hash(expected).Hex()).
+			Str("actual", common.Hash(actual).Hex()).
+			Msg("Exit root is inconsistent")
+	}
+
+	r.exitRootConsistencies = append(r.exitRootConsistencies, observer.ExitRootConsistency{
+		Root:            root,
+		Consistent:      &consistent,
+		PendingDeposits: pending,
+	})
+}
+
 func (r *RPCProvider) refreshGlobalExitRoot(ctx context.Context, c *ethclient.Client, contract *contracts.PolygonZkEVMGlobalExitRootV2, co *bind.CallOpts) {
 	globalExitRoot, err := contract.GetLastGlobalExitRoot(co)
 	if err != nil {
@@ -1091,6 +1860,10 @@ func (r *RPCProvider) refreshExitRootsL2(ctx context.Context, c *ethclient.Clien
 
 	r.rollupExitRootL2 = refreshExitRoot(r.rollupExitRootL2, rollupExitRoot, time.Now())
 
+	if r.exitRootConsistencyEnabled {
+		r.checkL2GlobalExitRoots(contract, &co)
+	}
+
 	return nil
 }
 
@@ -1101,6 +1874,7 @@ func (r *RPCProvider) refreshBridge(ctx context.Context, c *ethclient.Client) er
 
 	r.bridgeEvents = nil
 	r.claimEvents = nil
+	r.bridgeTokenFlows = make(map[observer.BridgeToken]*observer.BridgeTokenFlow)
 
 	co := bind.CallOpts{Context: ctx}
 	address := common.HexToAddress(*r.contracts.ZkEVMBridgeAddress)
@@ -1124,13 +1898,55 @@ func (r *RPCProvider) refreshBridge(ctx context.Context, c *ethclient.Client) er
 		r.lastUpdatedDepositCount = &ludc
 	}
 
+	r.bridgeActivity = nil
+	if r.bridgeReconciliationEnabled {
+		r.bridgeActivity = r.newBridgeActivity(contract, address, &co)
+	}
+
+	if r.exitRootConsistencyEnabled {
+		r.checkLocalExitRoot(ctx, c, contract)
+	}
+
 	opts := r.getFilterOpts()
 	r.refreshBridgeEvents(ctx, c, contract, opts)
 	r.refreshClaimEvents(ctx, c, contract, opts)
+	r.refreshBridgeLockedBalances(ctx, c, address)
 
 	return nil
 }
 
+// newBridgeActivity returns the bridge activity used to reconcile deposits and
+// claims, or nil if the bridge's network ID can't be determined.
+func (r *RPCProvider) newBridgeActivity(contract *contracts.PolygonZkEVMBridgeV2, address common.Address, co *bind.CallOpts) *observer.BridgeActivity {
+	id := r.getBridgeNetworkID(contract, co)
+	if id == nil {
+		return nil
+	}
+
+	activity := &observer.BridgeActivity{Bridge: address, NetworkID: *id}
+	if r.depositCount != nil {
+		dc := uint32(r.depositCount.Uint64())
+		activity.DepositCount = &dc
+	}
+
+	return activity
+}
+
+// getBridgeNetworkID returns the cached bridge network ID, fetching it from the
+// contract if it hasn't been fetched yet.
+func (r *RPCProvider) getBridgeNetworkID(contract *contracts.PolygonZkEVMBridgeV2, co *bind.CallOpts) *uint32 {
+	if r.bridgeNetworkID == nil {
+		id, err := contract.NetworkID(co)
+		if err != nil {
+			r.logger.Error().Err(err).Msg("Failed to get bridge network ID")
+			return nil
+		}
+		r.bridgeNetworkID = &id
+	}
+
+	return r.bridgeNetworkID
+}
+
 func (r *RPCProvider) refreshBridgeEvents(ctx context.Context, c *ethclient.Client, contract *contracts.PolygonZkEVMBridgeV2, opts *bind.FilterOpts) {
 	iter, err := contract.FilterBridgeEvent(opts)
 	if err != nil {
@@ -1153,7 +1969,26 @@ func (r *RPCProvider) refreshBridgeEvents(ctx context.Context, c *ethclient.Clie
 			DestinationNetwork: event.DestinationNetwork,
 		}
 
-		r.bridgeEventTimes[networks] = time.Unix(int64(block.Time()), 0)
+		t := time.Unix(int64(block.Time()), 0)
+		r.bridgeEventTimes[networks] = t
+
+		// Leaf type 1 is a message rather than an asset. The flows are counters,
+		// so events that were already scanned aren't added again.
+		if event.LeafType == 0 && !r.isScanned(event.Raw.BlockNumber) {
+			r.addBridgeTokenFlow(ctx, c, contract, event.OriginNetwork, event.OriginAddress, event.Amount, false)
+		}
+
+		if r.bridgeActivity != nil {
+			r.bridgeActivity.Deposits = append(r.bridgeActivity.Deposits, observer.BridgeDeposit{
+				DestinationNetwork: event.DestinationNetwork,
+				DepositCount:       event.DepositCount,
+				LeafType:           event.LeafType,
+				OriginNetwork:      event.OriginNetwork,
+				OriginAddress:      event.OriginAddress,
+				Amount:             event.Amount,
+				Time:               t,
+			})
+		}
 	}
 }
 
@@ -1174,7 +2009,198 @@ func (r *RPCProvider) refreshClaimEvents(ctx context.Context, c *ethclient.Clien
 			continue
 		}
 
-		r.claimEventTimes[event.OriginNetwork] = time.Unix(int64(block.Time()), 0)
+		t := time.Unix(int64(block.Time()), 0)
+		r.claimEventTimes[event.OriginNetwork] = t
+
+		if !r.isScanned(event.Raw.BlockNumber) {
+			r.addBridgeTokenFlow(ctx, c, contract, event.OriginNetwork, event.OriginAddress, event.Amount, true)
+		}
+
+		if r.bridgeActivity != nil {
+			r.bridgeActivity.Claims = append(r.bridgeActivity.Claims, observer.BridgeClaim{
+				GlobalIndex: event.GlobalIndex,
+				Amount:      event.Amount,
+				Time:        t,
+			})
+		}
+	}
+}
+
+// etherMetadata is the token metadata of bridged ether, which is identified by
+// the zero address.
+var etherMetadata = &tokenMetadata{symbol: "eth", decimals: 18}
+
+// getBridgeTokenMetadata returns the token metadata of a bridged token. Tokens
+// that originate from this network are read directly, and tokens from other
+// networks are read from their wrapped token.
+func (r *RPCProvider) getBridgeTokenMetadata(ctx context.Context, c *ethclient.Client, contract *contracts.PolygonZkEVMBridgeV2, token observer.BridgeToken) (*tokenMetadata, error) {
+	if token.OriginAddress == (common.Address{}) {
+		return etherMetadata, nil
+	}
+
+	co := &bind.CallOpts{Context: ctx}
+	address := token.OriginAddress
+
+	id := r.getBridgeNetworkID(contract, co)
+	if id == nil {
+		return nil, errors.New("unknown bridge network ID")
+	}
+
+	if token.OriginNetwork != *id {
+		wrapped, err := r.getWrappedToken(contract, co, token)
+		if err != nil {
+			return nil, err
+		}
+
+		if wrapped == (common.Address{}) {
+			return nil, errors.New("token is not wrapped")
+		}
+		address = wrapped
+	}
+
+	if metadata, ok := r.tokenMetadata[address]; ok {
+		return metadata, nil
+	}
+
+	erc20, err := contracts.NewERC20(address, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.getTokenMetadata(erc20, config.Token{Address: address.Hex()}, co)
+}
+
+// wrappedTokenRetryInterval is how long a token that isn't wrapped on this
+// network is cached before it's checked again. Tokens are wrapped when they're
+// first claimed, so a missing wrapped token can't be cached forever.
+const wrappedTokenRetryInterval = 10 * time.Minute
+
+// wrappedToken is the cached wrapped address of a bridged token, which is the
+// zero address if the token wasn't wrapped when it was checked.
+type wrappedToken struct {
+	address common.Address
+	checked time.Time
+}
+
+// getWrappedToken returns the address of the token wrapped by the bridge on
+// this network.
+func (r *RPCProvider) getWrappedToken(contract *contracts.PolygonZkEVMBridgeV2, co *bind.CallOpts, token observer.BridgeToken) (common.Address, error) {
+	if w, ok := r.wrappedTokens[token]; ok {
+		if w.address != (common.Address{}) || time.Since(w.checked) < wrappedTokenRetryInterval {
+			return w.address, nil
+		}
+	}
+
+	address, err := contract.GetTokenWrappedAddress(co, token.OriginNetwork, token.OriginAddress)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	r.wrappedTokens[token] = wrappedToken{address: address, checked: time.Now()}
+
+	return address, nil
+}
+
+// addBridgeTokenFlow adds the amount to the token's inflow if it was claimed
+// on this network or its outflow if it was deposited from this network.
+func (r *RPCProvider) addBridgeTokenFlow(ctx context.Context, c *ethclient.Client, contract *contracts.PolygonZkEVMBridgeV2, originNetwork uint32, originAddress common.Address, amount *big.Int, inflow bool) {
+	if amount == nil {
+		return
+	}
+
+	token := observer.BridgeToken{OriginNetwork: originNetwork, OriginAddress: originAddress}
+
+	metadata, err := r.getBridgeTokenMetadata(ctx, c, contract, token)
+	if err != nil {
+		r.logger.Debug().Err(err).Any("token", token).Msg("Failed to get bridge token metadata")
+		return
+	}
+
+	flow, ok := r.bridgeTokenFlows[token]
+	if !ok {
+		flow = &observer.BridgeTokenFlow{
+			BridgeToken: token,
+			Symbol:      metadata.symbol,
+			Inflow:      new(big.Float),
+			Outflow:     new(big.Float),
+		}
+		r.bridgeTokenFlows[token] = flow
+	}
+
+	value := metadata.value(amount)
+	usd := r.usd(metadata.symbol, value)
+
+	if inflow {
+		flow.Inflow.Add(flow.Inflow, value)
+		flow.InflowUSD = addUSD(flow.InflowUSD, usd)
+	} else {
+		flow.Outflow.Add(flow.Outflow, value)
+		flow.OutflowUSD = addUSD(flow.OutflowUSD, usd)
+	}
+}
+
+func addUSD(total, usd *float64) *float64 {
+	if usd == nil {
+		return total
+	}
+
+	if total == nil {
+		total = new(float64)
+	}
+	*total += *usd
+
+	return total
+}
+
+// refreshBridgeLockedBalances fetches the balances of the configured tokens
+// locked in the bridge contract.
+func (r *RPCProvider) refreshBridgeLockedBalances(ctx context.Context, c *ethclient.Client, bridge common.Address) {
+	r.bridgeLockedBalances = nil
+	co := &bind.CallOpts{Context: ctx}
+
+	for _, token := range r.bridgeTokens {
+		address := common.HexToAddress(token.Address)
+
+		var balance *big.Int
+		var metadata *tokenMetadata
+		var err error
+
+		if address == (common.Address{}) {
+			metadata = etherMetadata
+			if len(token.Symbol) > 0 {
+				metadata = &tokenMetadata{symbol: strings.ToLower(token.Symbol), decimals: 18}
+			}
+
+			balance, err = c.BalanceAt(ctx, bridge, nil)
+		} else {
+			var erc20 *contracts.ERC20
+			erc20, err = contracts.NewERC20(address, c)
+			if err != nil {
+				r.logger.Error().Err(err).Any("token", address).Msg("Failed to bind ERC20 contract")
+				continue
+			}
+
+			metadata, err = r.getTokenMetadata(erc20, token, co)
+			if err != nil {
+				r.logger.Error().Err(err).Any("token", address).Msg("Failed to get ERC20 token metadata")
+				continue
+			}
+
+			balance, err = erc20.BalanceOf(co, bridge)
+		}
+
+		if err != nil || balance == nil {
+			r.logger.Error().Err(err).Any("token", address).Msg("Failed to get bridge locked balance")
+			continue
+		}
+
+		value := metadata.value(balance)
+		r.bridgeLockedBalances = append(r.bridgeLockedBalances, &observer.BridgeLockedBalance{
+			Token:   address,
+			Symbol:  metadata.symbol,
+			Balance: value,
+			USD:     r.usd(metadata.symbol, value),
+		})
 	}
 }
 
@@ -1229,88 +2255,152 @@ func (r *RPCProvider) refreshTrustedSequencerBalance(ctx context.Context, c *eth
 	balances.POL = r.getPOL(c, address, co, balances.POL)
 }
 
-func (r *RPCProvider) newRollupNetwork(rollupID uint32) network.Network {
-	var name string
+// getRollupConfig returns the rollup's configuration, matching the rollup ID
+// before the chain ID. Rollups that aren't configured return an empty
+// configuration.
+func (r *RPCProvider) getRollupConfig(rollupID uint32, chainID uint64) config.Rollup {
+	for _, rollup := range r.rollups {
+		if rollup.RollupID != nil && *rollup.RollupID == rollupID {
+			return rollup
+		}
+	}
 
-	switch r.Network.GetName() {
-	case network.EthereumName:
-		name = "Mainnet"
-	case network.SepoliaName:
-		if strings.HasPrefix(r.Label, "cardona.") {
-			name = "Cardona"
-		} else if strings.HasPrefix(r.Label, "bali.") {
-			name = "Bali"
+	for _, rollup := range r.rollups {
+		if rollup.RollupID == nil && rollup.ChainID != nil && *rollup.ChainID == chainID {
+			return rollup
 		}
-	default:
-		r.logger.Error().
-			Str("network", r.Network.GetName()).
-			Msg("Failed to create new rollup network")
+	}
 
-		return nil
+	return config.Rollup{}
+}
+
+// newRollupNetwork returns the rollup's network. The name is taken from the
+// rollup configuration, otherwise it is derived from the L1 network.
+func (r *RPCProvider) newRollupNetwork(rollupID uint32, chainID uint64, rollup config.Rollup) network.Network {
+	if len(rollup.Name) > 0 {
+		return &config.Network{Name: rollup.Name, ChainID: chainID}
 	}
 
-	if len(name) == 0 {
-		r.logger.Error().Msg("New rollup network has no name")
-		return nil
+	// These names are kept for the networks that were supported before rollup
+	// names were configurable.
+	name := r.Network.GetName()
+	switch {
+	case name == network.EthereumName:
+		name = "Mainnet"
+	case name == network.SepoliaName && strings.HasPrefix(r.Label, "cardona."):
+		name = "Cardona"
+	case name == network.SepoliaName && strings.HasPrefix(r.Label, "bali."):
+		name = "Bali"
 	}
 
 	return &config.Network{
-		Name: fmt.Sprintf("%s Rollup %d", name, rollupID),
+		Name:    fmt.Sprintf("%s Rollup %d", name, rollupID),
+		ChainID: chainID,
 	}
 }
 
-func (r *RPCProvider) refreshTrustedSequencerURL(ctx context.Context, contract *contracts.PolygonZkEVMEtrog, co *bind.CallOpts, rollupID uint32) error {
-	url, err := contract.TrustedSequencerURL(co)
-	if err != nil {
-		return err
+func (r *RPCProvider) refreshTrustedSequencerURL(ctx context.Context, contract *contracts.PolygonZkEVMEtrog, co *bind.CallOpts, rollupID uint32, chainID uint64) error {
+	rollup := r.getRollupConfig(rollupID, chainID)
+	if rollup.Disabled {
+		r.removeTrustedSequencer(rollupID)
+		return nil
+	}
+
+	url := rollup.TrustedSequencerURL
+	if len(url) == 0 {
+		var err error
+		url, err = contract.TrustedSequencerURL(co)
+		if err != nil {
+			return err
+		}
 	}
 
 	if url == "https://decomm.invalid" {
+		r.removeTrustedSequencer(rollupID)
 		return nil
 	}
 
-	rollupNetwork := r.newRollupNetwork(rollupID)
-	if rollupNetwork == nil {
+	if r.manager == nil {
 		return nil
 	}
 
-	provider, ok := r.trustedSequencers[rollupID]
-	if !ok {
-		r.trustedSequencers[rollupID] = NewRPCProvider(RPCProviderOpts{
-			Network:  rollupNetwork,
-			URL:      url,
-			Label:    url,
-			EventBus: r.bus,
-			Interval: r.interval,
-		})
-		go runProvider(ctx, r.trustedSequencers[rollupID])
-		return nil
+	// Replace the provider when the trusted sequencer URL changes.
+	if provider, ok := r.trustedSequencers[rollupID]; ok {
+		if provider.URL == url {
+			return nil
+		}
+
+		r.stopTrustedSequencer(rollupID)
+	}
+
+	rollupNetwork := r.newRollupNetwork(rollupID, chainID, rollup)
+
+	label := rollup.Label
+	if len(label) == 0 {
+		label = url
 	}
 
-	if provider.URL != url {
-		provider.trustedSequencerURL <- url
+	interval := r.interval
+	if rollup.Interval > 0 {
+		interval = rollup.Interval
 	}
 
+	r.logger.Info().
+		Uint32("rollup_id", rollupID).
+		Str("rollup_network", rollupNetwork.GetName()).
+		Str("url", url).
+		Msg("Monitoring trusted sequencer")
+
+	provider := NewRPCProvider(RPCProviderOpts{
+		Network:  rollupNetwork,
+		URL:      url,
+		Label:    label,
+		EventBus: r.bus,
+		Interval: interval,
+		Manager:  r.manager,
+
+		StateSync:            r.stateSyncEnabled,
+		BridgeReconciliation: r.bridgeReconciliationEnabled,
+		ExitRootConsistency:  r.exitRootConsistencyEnabled,
+		BatchPipeline:        r.batchPipelineEnabled,
+	})
+	r.trustedSequencers[rollupID] = provider
+	r.manager.Start(provider)
+
 	return nil
 }
 
-func runProvider(ctx context.Context, p *RPCProvider) {
-	for {
-		select {
-		case url := <-p.trustedSequencerURL:
-			p.URL = url
-		default:
-			if err := p.RefreshState(ctx); err != nil {
-				p.logger.Error().Err(err).Send()
-			}
+// stopTrustedSequencer stops the rollup's trusted sequencer provider and
+// deletes its series.
+func (r *RPCProvider) stopTrustedSequencer(rollupID uint32) {
+	provider, ok := r.trustedSequencers[rollupID]
+	if !ok {
+		return
+	}
 
-			if err := p.PublishEvents(ctx); err != nil {
-				p.logger.Error().Err(err).Send()
-			}
+	r.logger.Info().
+		Uint32("rollup_id", rollupID).
+		Str("rollup_network", provider.Network.GetName()).
+		Str("url", provider.URL).
+		Msg("Stopping trusted sequencer monitoring")
 
-			util.BlockFor(ctx, time.Second*time.Duration(p.PollingInterval()))
-		}
+	r.manager.Stop(provider)
+	delete(r.trustedSequencers, rollupID)
+	r.bus.DeleteSeries(provider.Network, provider.Label)
+}
+
+// removeTrustedSequencer stops the rollup's trusted sequencer provider and
+// removes the rollup network from the meta-providers, for rollups that are no
+// longer monitored. A trusted sequencer whose URL changed is only stopped, so
+// the meta-providers keep their state for the replacement provider.
+func (r *RPCProvider) removeTrustedSequencer(rollupID uint32) {
+	provider, ok := r.trustedSequencers[rollupID]
+	if !ok {
+		return
 	}
+
+	r.stopTrustedSequencer(rollupID)
+	r.manager.RemoveNetwork(provider.Network)
 }
 
 func (r *RPCProvider) refreshZkEVMEtrog(ctx context.Context, c *ethclient.Client, co *bind.CallOpts, rollupID uint32, rollup RollupData) error {
@@ -1340,12 +2430,103 @@ func (r *RPCProvider) refreshZkEVMEtrog(ctx context.Context, c *ethclient.Client
 		r.rollupManager.Rollups[rollupID].LastForceBatchSequenced = &lfbs
 	}
 
+	r.refreshForcedBatches(ctx, c, contract, co, rollupID)
+
 	r.refreshTrustedSequencerBalance(ctx, c, contract, co, rollupID)
-	r.refreshTrustedSequencerURL(ctx, contract, co, rollupID)
+	r.refreshTrustedSequencerURL(ctx, contract, co, rollupID, rollup.ChainID)
 
 	return nil
 }
 
+// maxForcedBatchBackfill is the maximum number of forced batches backfilled per
+// refresh.
+const maxForcedBatchBackfill = 100
+
+// rollupForcedBatches is the forced batch state of a rollup.
+type rollupForcedBatches struct {
+	// times maps the forced batches that haven't been sequenced to their
+	// submission time.
+	times map[uint64]time.Time
+
+	// backfilled is the last forced batch that was searched for outside the
+	// filtered block range.
+	backfilled uint64
+}
+
+// refreshForcedBatches tracks the submission time of the forced batches that
+// haven't been sequenced. Forced batches between the last sequenced and the
+// last forced batch that weren't submitted within the block look back are
+// backfilled once by filtering on their indexed batch number.
+func (r *RPCProvider) refreshForcedBatches(ctx context.Context, c *ethclient.Client, contract *contracts.PolygonZkEVMEtrog, co *bind.CallOpts, rollupID uint32) {
+	rollup := r.rollupManager.Rollups[rollupID]
+
+	timeout, err := contract.ForceBatchTimeout(co)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Could not get force batch timeout")
+	} else {
+		rollup.ForceBatchTimeout = &timeout
+	}
+
+	if r.forcedBatches == nil {
+		r.forcedBatches = make(map[uint32]*rollupForcedBatches)
+	}
+
+	fb, ok := r.forcedBatches[rollupID]
+	if !ok {
+		fb = &rollupForcedBatches{times: make(map[uint64]time.Time)}
+		r.forcedBatches[rollupID] = fb
+	}
+
+	r.addForcedBatches(ctx, c, contract, r.getFilterOpts(), nil, fb.times)
+
+	if lfb, lfbs := rollup.LastForceBatch, rollup.LastForceBatchSequenced; lfb != nil && lfbs != nil && *lfb > fb.backfilled {
+		var missing []uint64
+		num := max(*lfbs, fb.backfilled)
+		for num < *lfb && len(missing) < maxForcedBatchBackfill {
+			num++
+			if _, ok := fb.times[num]; !ok {
+				missing = append(missing, num)
+			}
+		}
+		fb.backfilled = num
+
+		if len(missing) > 0 {
+			opts := &bind.FilterOpts{End: &r.BlockNumber, Context: ctx}
+			r.addForcedBatches(ctx, c, contract, opts, missing, fb.times)
+		}
+	}
+
+	rollup.PendingForcedBatches = nil
+	for num, t := range fb.times {
+		if rollup.LastForceBatchSequenced != nil && num <= *rollup.LastForceBatchSequenced {
+			delete(fb.times, num)
+			continue
+		}
+
+		rollup.PendingForcedBatches = append(rollup.PendingForcedBatches, observer.ForcedBatch{Number: num, Time: t})
+	}
+}
+
+// addForcedBatches adds the submission time of the forced batches filtered by
+// their batch numbers, or every forced batch if nums is empty.
+func (r *RPCProvider) addForcedBatches(ctx context.Context, c *ethclient.Client, contract *contracts.PolygonZkEVMEtrog, opts *bind.FilterOpts, nums []uint64, times map[uint64]time.Time) {
+	iter, err := contract.FilterForceBatch(opts, nums)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to filter force batch events")
+		return
+	}
+
+	for iter.Next() && iter.Event != nil {
+		header, err := c.HeaderByHash(ctx, iter.Event.Raw.BlockHash)
+		if err != nil {
+			r.logger.Error().Err(err).Msg("Failed to get header by hash")
+			continue
+		}
+
+		times[iter.Event.ForceBatchNum] = time.Unix(int64(header.Time), 0)
+	}
+}
+
 func (r *RPCProvider) refreshAggregatorBalances(ctx context.Context, c *ethclient.Client, aggregator common.Address) {
 	eth, err := c.BalanceAt(ctx, aggregator, nil)
 	if err != nil || eth == nil {
@@ -1379,6 +2560,16 @@ func (r *RPCProvider) refreshRollupManager(ctx context.Context, c *ethclient.Cli
 		rollup.VerifiedBatchesTxFees = nil
 	}
 
+	if r.economicsStart.IsZero() {
+		r.economicsStart = time.Now()
+	}
+
+	r.rollupEconomics = &observer.RollupEconomics{
+		Aggregators:                 make(map[common.Address]*observer.AggregatorEconomics),
+		TrustedSequencerTimeToEmpty: make(map[uint32]*time.Duration),
+		AggregatorTimeToEmpty:       make(map[common.Address]*time.Duration),
+	}
+
 	co := &bind.CallOpts{Context: ctx}
 	address := common.HexToAddress(*r.contracts.RollupManagerAddress)
 	contract, err := contracts.NewPolygonRollupManager(address, c)
@@ -1408,6 +2599,11 @@ func (r *RPCProvider) refreshRollupManager(ctx context.Context, c *ethclient.Cli
 
 	opts := r.getFilterOpts()
 
+	r.rollupBatchStages = nil
+	if r.batchPipelineEnabled {
+		r.rollupBatchStages = make(observer.RollupBatchStages)
+	}
+
 	r.refreshBatchFees(contract, co)
 	r.refreshBatchTotals(contract, co)
 	r.refreshRollupCounts(contract, co)
@@ -1415,10 +2611,213 @@ func (r *RPCProvider) refreshRollupManager(ctx context.Context, c *ethclient.Cli
 	r.refreshOnSequenceBatches(ctx, c, contract, opts)
 	r.refreshRollupVerifyBatches(ctx, c, contract, opts)
 	r.refreshRollupVerifyBatchesTrustedAggregator(ctx, c, contract, opts)
+	r.refreshRollupEconomics()
+
+	for id, rollup := range r.rollupManager.Rollups {
+		if r.rollupBatchStages == nil || rollup.Pessimistic {
+			continue
+		}
+
+		stages, ok := r.rollupBatchStages[id]
+		if !ok {
+			stages = &observer.RollupBatchStage{}
+			r.rollupBatchStages[id] = stages
+		}
+
+		stages.ChainID = rollup.ChainID
+		stages.LastBatchSequenced = rollup.LastBatchSequenced
+		stages.LastVerifiedBatch = rollup.LastVerifiedBatch
+	}
 
 	return nil
 }
 
+// burnRateWindow is the window of L1 transaction fees used to calculate the
+// burn rate of the trusted sequencer and aggregator balances.
+const burnRateWindow = 24 * time.Hour
+
+// rollupSpend is an L1 transaction fee paid by a trusted sequencer or an
+// aggregator.
+type rollupSpend struct {
+	fee  *big.Int
+	time time.Time
+}
+
+// addSequencedBatchCost records the fee of a sequence batches transaction. The
+// number of batches isn't known for the first event, so its cost per batch is
+// skipped.
+func (r *RPCProvider) addSequencedBatchCost(rollupID uint32, fee *big.Int, batches uint64, blockTime uint64) {
+	if r.sequencerSpends == nil {
+		r.sequencerSpends = make(map[uint32][]rollupSpend)
+	}
+
+	t := time.Unix(int64(blockTime), 0)
+	r.sequencerSpends[rollupID] = append(r.sequencerSpends[rollupID], rollupSpend{fee: fee, time: t})
+
+	if batches == 0 {
+		return
+	}
+
+	cost := new(big.Int).Div(fee, new(big.Int).SetUint64(batches))
+	r.rollupEconomics.SequencedBatchCosts = append(r.rollupEconomics.SequencedBatchCosts, observer.RollupBatchCost{
+		RollupID: rollupID,
+		Fee:      cost,
+		USD:      r.usd(etherMetadata.symbol, etherMetadata.value(cost)),
+	})
+}
+
+// addVerifiedBatchCost records the fee of a verify batches transaction and the
+// aggregator's POL reward for the verified batches.
+func (r *RPCProvider) addVerifiedBatchCost(rollupID uint32, aggregator common.Address, fee *big.Int, batches uint64, blockTime uint64) {
+	if r.aggregatorSpends == nil {
+		r.aggregatorSpends = make(map[common.Address][]rollupSpend)
+	}
+
+	t := time.Unix(int64(blockTime), 0)
+	r.aggregatorSpends[aggregator] = append(r.aggregatorSpends[aggregator], rollupSpend{fee: fee, time: t})
+
+	economics, ok := r.rollupEconomics.Aggregators[aggregator]
+	if !ok {
+		economics = &observer.AggregatorEconomics{Reward: new(big.Int), Spent: new(big.Int)}
+		r.rollupEconomics.Aggregators[aggregator] = economics
+	}
+
+	economics.Spent.Add(economics.Spent, fee)
+
+	if batches == 0 {
+		return
+	}
+
+	if r.rollupManager.RewardPerBatch != nil {
+		reward := new(big.Int).Mul(r.rollupManager.RewardPerBatch, new(big.Int).SetUint64(batches))
+		economics.Reward.Add(economics.Reward, reward)
+	}
+
+	cost := new(big.Int).Div(fee, new(big.Int).SetUint64(batches))
+	r.rollupEconomics.VerifiedBatchCosts = append(r.rollupEconomics.VerifiedBatchCosts, observer.RollupBatchCost{
+		RollupID: rollupID,
+		Fee:      cost,
+		USD:      r.usd(etherMetadata.symbol, etherMetadata.value(cost)),
+	})
+}
+
+// refreshRollupEconomics converts the aggregator rewards and spends to USD and
+// projects the time until the trusted sequencer and aggregator ETH balances are
+// empty. The burn rate is the fees paid within the burn rate window, or since
+// the oldest spend if that's shorter, with a minimum of an hour so the first
+// few events don't project a balance to be empty immediately.
+func (r *RPCProvider) refreshRollupEconomics() {
+	pol := &tokenMetadata{symbol: observer.POL, decimals: 18}
+	for _, economics := range r.rollupEconomics.Aggregators {
+		economics.RewardUSD = r.usd(pol.symbol, pol.value(economics.Reward))
+		economics.SpentUSD = r.usd(etherMetadata.symbol, etherMetadata.value(economics.Spent))
+	}
+
+	// The first refresh backfills the spends within the block look back, which
+	// predate the provider starting, so the window starts at the oldest spend.
+	for _, spends := range r.sequencerSpends {
+		if len(spends) > 0 && spends[0].time.Before(r.economicsStart) {
+			r.economicsStart = spends[0].time
+		}
+	}
+	for _, spends := range r.aggregatorSpends {
+		if len(spends) > 0 && spends[0].time.Before(r.economicsStart) {
+			r.economicsStart = spends[0].time
+		}
+	}
+
+	window := min(burnRateWindow, max(time.Since(r.economicsStart), time.Hour))
+
+	// Accounts without spends left in the window are published once with a nil
+	// time to empty, so their series are deleted.
+	for id, spends := range r.sequencerSpends {
+		spends = pruneRollupSpends(spends)
+		r.sequencerSpends[id] = spends
+		if len(spends) == 0 {
+			delete(r.sequencerSpends, id)
+		}
+
+		rollup, ok := r.rollupManager.Rollups[id]
+		if !ok {
+			continue
+		}
+
+		r.rollupEconomics.TrustedSequencerTimeToEmpty[id] = timeToEmpty(rollup.TrustedSequencerBalances.ETH, spends, window)
+	}
+
+	for address, spends := range r.aggregatorSpends {
+		spends = pruneRollupSpends(spends)
+		r.aggregatorSpends[address] = spends
+		if len(spends) == 0 {
+			delete(r.aggregatorSpends, address)
+		}
+
+		balances := r.rollupManager.AggregatorBalances[address]
+		r.rollupEconomics.AggregatorTimeToEmpty[address] = timeToEmpty(balances.ETH, spends, window)
+	}
+}
+
+// pruneRollupSpends removes the spends older than the burn rate window.
+func pruneRollupSpends(spends []rollupSpend) []rollupSpend {
+	pruned := spends[:0]
+	for _, spend := range spends {
+		if time.Since(spend.time) <= burnRateWindow {
+			pruned = append(pruned, spend)
+		}
+	}
+
+	return pruned
+}
+
+// timeToEmpty returns the time until the balance is empty if the spends
+// continue at the same rate, or nil if nothing was spent.
+func timeToEmpty(balance *big.Int, spends []rollupSpend, window time.Duration) *time.Duration {
+	if balance == nil {
+		return nil
+	}
+
+	spent := new(big.Int)
+	for _, spend := range spends {
+		spent.Add(spent, spend.fee)
+	}
+
+	if spent.Sign() == 0 {
+		return nil
+	}
+
+	// balance / (spent / window) = balance * window / spent
+	tte := new(big.Int).Mul(balance, big.NewInt(int64(window)))
+	tte.Div(tte, spent)
+
+	if !tte.IsInt64() {
+		return nil
+	}
+
+	d := time.Duration(tte.Int64())
+	return &d
+}
+
+// addBatchStage records that the batches up to the batch number were
+// virtualized or verified at the L1 block time.
+func (r *RPCProvider) addBatchStage(rollupID uint32, batch uint64, blockTime uint64, verified bool) {
+	if r.rollupBatchStages == nil {
+		return
+	}
+
+	stages, ok := r.rollupBatchStages[rollupID]
+	if !ok {
+		stages = &observer.RollupBatchStage{}
+		r.rollupBatchStages[rollupID] = stages
+	}
+
+	event := observer.BatchStageEvent{Batch: batch, Time: time.Unix(int64(blockTime), 0)}
+	if verified {
+		stages.Verified = append(stages.Verified, event)
+	} else {
+		stages.Sequenced = append(stages.Sequenced, event)
+	}
+}
+
 func (r *RPCProvider) refreshZkEVMContracts(contract *contracts.PolygonRollupManager, co *bind.CallOpts) error {
 	if r.contracts.ZkEVMBridgeAddress == nil {
 		bridgeAddress, err := contract.BridgeAddress(co)
@@ -1483,6 +2882,13 @@ func (r *RPCProvider) refreshRollups(ctx context.Context, c *ethclient.Client, c
 
 		r.refreshZkEVMEtrog(ctx, c, co, id, rollup)
 	}
+
+	// Stop the trusted sequencers of rollups that were removed.
+	for id := range r.trustedSequencers {
+		if id > *r.rollupManager.RollupCount {
+			r.removeTrustedSequencer(id)
+		}
+	}
 }
 
 func (r *RPCProvider) refreshBatchFees(contract *contracts.PolygonRollupManager, co *bind.CallOpts) {
@@ -1557,11 +2963,13 @@ func (r *RPCProvider) refreshOnSequenceBatches(ctx context.Context, c *ethclient
 			continue
 		}
 
+		var batches uint64
 		if rollup.LastBatchSequenced != nil {
 			if *rollup.LastBatchSequenced >= event.LastBatchSequenced {
 				continue
 			}
 
+			batches = event.LastBatchSequenced - *rollup.LastBatchSequenced
 			rollup.TimeBetweenSequencedBatches = append(
 				rollup.TimeBetweenSequencedBatches,
 				time-*rollup.LastSequencedTimestamp,
@@ -1573,6 +2981,7 @@ func (r *RPCProvider) refreshOnSequenceBatches(ctx context.Context, c *ethclient
 
 		rollup.LastSequencedTimestamp = &time
 		rollup.LastBatchSequenced = &event.LastBatchSequenced
+		r.addBatchStage(event.RollupID, event.LastBatchSequenced, time, false)
 
 		receipt, err := c.TransactionReceipt(ctx, event.Raw.TxHash)
 		if err != nil {
@@ -1585,6 +2994,7 @@ func (r *RPCProvider) refreshOnSequenceBatches(ctx context.Context, c *ethclient
 			Fee:     fee,
 			Address: event.Raw.Address,
 		})
+		r.addSequencedBatchCost(event.RollupID, fee, batches, time)
 	}
 }
 
@@ -1614,11 +3024,14 @@ func (r *RPCProvider) refreshRollupVerifyBatches(ctx context.Context, c *ethclie
 			continue
 		}
 
+		var batches uint64
 		if rollup.LastVerifiedBatch != nil {
 			if *rollup.LastVerifiedBatch >= event.NumBatch {
 				continue
 			}
 
+			batches = event.NumBatch - *rollup.LastVerifiedBatch
+
 			// There should not be an instance where a rollup has events for both the
 			// VerifyBatches and VerifyBatchesTrustedAggregator, so the
 			// TimeBetweenVerifiedBatches and VerifiedBatchesTxFees slices should not
@@ -1634,6 +3047,7 @@ func (r *RPCProvider) refreshRollupVerifyBatches(ctx context.Context, c *ethclie
 
 		rollup.LastVerifiedTimestamp = &time
 		rollup.LastVerifiedBatch = &event.NumBatch
+		r.addBatchStage(event.RollupID, event.NumBatch, time, true)
 
 		receipt, err := c.TransactionReceipt(ctx, event.Raw.TxHash)
 		if err != nil {
@@ -1646,6 +3060,7 @@ func (r *RPCProvider) refreshRollupVerifyBatches(ctx context.Context, c *ethclie
 			Fee:     fee,
 			Address: event.Aggregator,
 		})
+		r.addVerifiedBatchCost(event.RollupID, event.Aggregator, fee, batches, time)
 	}
 }
 
@@ -1677,6 +3092,7 @@ func (r *RPCProvider) refreshRollupVerifyBatchesTrustedAggregator(ctx context.Co
 
 		pessimistic := event.NumBatch == 0 && event.StateRoot == [32]byte{}
 
+		var batches uint64
 		if rollup.LastVerifiedBatch != nil {
 			// Here, pessimistic chains are handled differently because the NumBatch
 			// will always be 0. The last verified timestamp is used to determine if
@@ -1693,6 +3109,10 @@ func (r *RPCProvider) refreshRollupVerifyBatchesTrustedAggregator(ctx context.Co
 				continue
 			}
 
+			if !pessimistic {
+				batches = event.NumBatch - *rollup.LastVerifiedBatch
+			}
+
 			// At this point rollup.LastVerifiedBatch and rollup.LastVerifiedTimestamp
 			// contain the data of the previous verified batch event, not the current
 			// one (which is stored in event). This is used to calculate the time
@@ -1713,6 +3133,11 @@ func (r *RPCProvider) refreshRollupVerifyBatchesTrustedAggregator(ctx context.Co
 		rollup.LastVerifiedBatch = &event.NumBatch
 		rollup.Pessimistic = pessimistic
 
+		// Pessimistic rollups don't have batches.
+		if !pessimistic {
+			r.addBatchStage(event.RollupID, event.NumBatch, time, true)
+		}
+
 		receipt, err := c.TransactionReceipt(ctx, event.Raw.TxHash)
 		if err != nil {
 			r.logger.Error().Err(err).Msg("Failed to get transaction receipt")
@@ -1724,5 +3149,6 @@ func (r *RPCProvider) refreshRollupVerifyBatchesTrustedAggregator(ctx context.Co
 			Fee:     fee,
 			Address: event.Aggregator,
 		})
+		r.addVerifiedBatchCost(event.RollupID, event.Aggregator, fee, batches, time)
 	}
 }
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rs/zerolog"
+
+	"github.com/0xPolygon/panoptichain/contracts"
+	"github.com/0xPolygon/panoptichain/observer"
+)
+
+// forceBatchNode is a stand-in for an L1 node with a rollup contract whose
+// forced batch n was submitted at block n * 100.
+type forceBatchNode struct {
+	t       *testing.T
+	batches uint64
+
+	mu      sync.Mutex
+	filters [][]uint64
+	methods map[string]int
+}
+
+func newForceBatchNode(t *testing.T, batches uint64) (*forceBatchNode, *httptest.Server) {
+	n := &forceBatchNode{t: t, batches: batches, methods: make(map[string]int)}
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var msg opStackRequest
+		if err := json.NewDecoder(req.Body).Decode(&msg); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(n.handle(msg)); err != nil {
+			t.Errorf("failed to encode response: %v", err)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return n, s
+}
+
+func (n *forceBatchNode) handle(msg opStackRequest) map[string]any {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.methods[msg.Method]++
+
+	parsed, err := contracts.PolygonZkEVMEtrogMetaData.GetAbi()
+	if err != nil {
+		n.t.Fatalf("failed to parse ABI: %v", err)
+	}
+
+	switch msg.Method {
+	case "eth_call":
+		packed, err := parsed.Methods["forceBatchTimeout"].Outputs.Pack(uint64(3600))
+		if err != nil {
+			n.t.Errorf("failed to pack force batch timeout: %v", err)
+		}
+		return reply(msg.ID, hexutil.Bytes(packed), "")
+
+	case "eth_getLogs":
+		var filter struct {
+			FromBlock hexutil.Big     `json:"fromBlock"`
+			ToBlock   hexutil.Big     `json:"toBlock"`
+			Topics    [][]common.Hash `json:"topics"`
+		}
+		if err := json.Unmarshal(msg.Params[0], &filter); err != nil {
+			n.t.Errorf("failed to decode filter: %v", err)
+		}
+
+		var nums []uint64
+		if len(filter.Topics) > 1 {
+			for _, topic := range filter.Topics[1] {
+				nums = append(nums, topic.Big().Uint64())
+			}
+		}
+		n.filters = append(n.filters, nums)
+
+		event := parsed.Events["ForceBatch"]
+		logs := []types.Log{}
+		for num := uint64(1); num <= n.batches; num++ {
+			block := num * 100
+			if block < filter.FromBlock.ToInt().Uint64() || block > filter.ToBlock.ToInt().Uint64() {
+				continue
+			}
+			if len(nums) > 0 && !slices.Contains(nums, num) {
+				continue
+			}
+
+			data, err := event.Inputs.NonIndexed().Pack(common.Hash{}, common.Address{}, []byte{})
+			if err != nil {
+				n.t.Errorf("failed to pack force batch event: %v", err)
+			}
+
+			logs = append(logs, types.Log{
+				Address:     common.Address{1},
+				Topics:      []common.Hash{event.ID, common.BigToHash(new(big.Int).SetUint64(num))},
+				Data:        data,
+				BlockNumber: block,
+				BlockHash:   common.BigToHash(new(big.Int).SetUint64(block)),
+			})
+		}
+		return reply(msg.ID, logs, "")
+
+	case "eth_getBlockByHash":
+		var hash common.Hash
+		if err := json.Unmarshal(msg.Params[0], &hash); err != nil {
+			n.t.Errorf("failed to decode hash: %v", err)
+		}
+
+		block := hash.Big()
+		return reply(msg.ID, &types.Header{
+			Number:     block,
+			Time:       block.Uint64() * 10,
+			Difficulty: new(big.Int),
+		}, "")
+	}
+
+	return reply(msg.ID, nil, "method not found")
+}
+
+func TestRefreshForcedBatches(t *testing.T) {
+	node, s := newForceBatchNode(t, 5)
+
+	c, err := ethclient.Dial(s.URL)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(c.Close)
+
+	contract, err := contracts.NewPolygonZkEVMEtrog(common.Address{1}, c)
+	if err != nil {
+		t.Fatalf("failed to bind contract: %v", err)
+	}
+
+	// Only the last forced batch was submitted within the block look back, and
+	// the first one was already sequenced.
+	var lfb, lfbs uint64 = 5, 1
+	r := &RPCProvider{
+		logger:        zerolog.Nop(),
+		BlockNumber:   1000,
+		blockLookBack: 550,
+		rollupManager: &observer.RollupManager{
+			Rollups: map[uint32]*observer.RollupData{
+				1: {LastForceBatch: &lfb, LastForceBatchSequenced: &lfbs},
+			},
+		},
+	}
+
+	pending := func() map[uint64]time.Time {
+		times := make(map[uint64]time.Time)
+		for _, batch := range r.rollupManager.Rollups[1].PendingForcedBatches {
+			times[batch.Number] = batch.Time
+		}
+		return times
+	}
+
+	want := make(map[uint64]time.Time)
+	for num := uint64(2); num <= 5; num++ {
+		want[num] = time.Unix(int64(num*1000), 0)
+	}
+
+	ctx := context.Background()
+	co := &bind.CallOpts{Context: ctx}
+	r.refreshForcedBatches(ctx, c, contract, co, 1)
+
+	if got := pending(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got pending forced batches %v, want %v", got, want)
+	}
+
+	if want := [][]uint64{nil, {2, 3, 4}}; !reflect.DeepEqual(node.filters, want) {
+		t.Errorf("got force batch filters %v, want %v", node.filters, want)
+	}
+
+	if node.methods["eth_getBlockByNumber"] > 0 || node.methods["eth_getBlockByHash"] != 4 {
+		t.Errorf("got methods %v, want 4 headers by hash", node.methods)
+	}
+
+	// The forced batches are only backfilled once, and the sequenced ones are
+	// no longer pending.
+	lfbs = 3
+	r.prevBlockNumber = r.BlockNumber
+	r.BlockNumber = 1100
+	r.refreshForcedBatches(ctx, c, contract, co, 1)
+
+	var nums []uint64
+	for num := range pending() {
+		nums = append(nums, num)
+	}
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+
+	if want := []uint64{4, 5}; !reflect.DeepEqual(nums, want) {
+		t.Errorf("got pending forced batches %v, want %v", nums, want)
+	}
+
+	if len(node.filters) != 3 || node.filters[2] != nil {
+		t.Errorf("got force batch filters %v, want a single unfiltered refresh", node.filters)
+	}
+}
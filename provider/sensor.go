@@ -321,7 +321,7 @@ func (s *SensorNetworkProvider) refreshReorgs(ctx context.Context) error {
 func (s *SensorNetworkProvider) getNonBogonBlocks() []*types.Block {
 	signers, err := api.Signers(s.Network)
 	if err != nil {
-		s.logger.Warn().Err(err).Msg("Failed to get signers validator map")
+		s.logger.Warn().Err(err).Msg("Failed to get signers")
 		return nil
 	}
 
@@ -336,9 +336,20 @@ func (s *SensorNetworkProvider) getNonBogonBlocks() []*types.Block {
 		}
 		signer := "0x" + hex.EncodeToString(bytes)
 
-		// Filter out bogon blocks.
+		// Filter out bogon blocks. Unknown signers force a validator set refresh
+		// in case the signer was rotated, and are treated as bogons if it fails.
 		if _, ok := signers[signer]; !ok {
-			continue
+			validator, ok, err := api.Signer(s.Network, signer)
+			if err != nil {
+				s.logger.Warn().Err(err).Msg("Failed to get signer validator")
+				continue
+			}
+
+			if !ok {
+				continue
+			}
+
+			signers[signer] = validator
 		}
 
 		blocks = append(blocks, block)
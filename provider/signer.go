@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/0xPolygon/panoptichain/config"
+)
+
+// signer signs transactions on behalf of a single account.
+type signer interface {
+	Address() common.Address
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// keySigner signs transactions with a private key held in memory.
+type keySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *keySigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.key.PublicKey)
+}
+
+func (s *keySigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+// externalSigner forwards transactions to a Clef compatible signer using the
+// account_signTransaction JSON-RPC method.
+type externalSigner struct {
+	signer  *external.ExternalSigner
+	account accounts.Account
+}
+
+func (s *externalSigner) Address() common.Address {
+	return s.account.Address
+}
+
+func (s *externalSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return s.signer.SignTx(s.account, tx, chainID)
+}
+
+// newSigner creates the signer for the time to mine sender. The signer is
+// configured with either a raw private key, an encrypted keystore file, or an
+// external signer. The signer address must match the sender.
+func newSigner(ttm *config.TimeToMine) (signer, error) {
+	sender := common.HexToAddress(ttm.Sender)
+
+	var s signer
+	switch {
+	case ttm.Signer == nil:
+		key, err := crypto.HexToECDSA(ttm.SenderPrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse sender private key: %w", err)
+		}
+
+		s = &keySigner{key: key}
+
+	case ttm.Signer.Type == "keystore":
+		data, err := os.ReadFile(ttm.Signer.Keystore)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keystore: %w", err)
+		}
+
+		password, err := os.ReadFile(ttm.Signer.PasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read password file: %w", err)
+		}
+
+		key, err := keystore.DecryptKey(data, strings.TrimRight(string(password), "\r\n"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+		}
+
+		s = &keySigner{key: key.PrivateKey}
+
+	case ttm.Signer.Type == "external":
+		es, err := external.NewExternalSigner(ttm.Signer.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to external signer: %w", err)
+		}
+
+		// The external signer signs for whichever account it's asked to, so
+		// the sender must be one of the accounts it manages.
+		var account *accounts.Account
+		for _, a := range es.Accounts() {
+			if a.Address == sender {
+				account = &a
+				break
+			}
+		}
+
+		if account == nil {
+			return nil, fmt.Errorf("sender %v is not an external signer account", sender)
+		}
+
+		s = &externalSigner{
+			signer:  es,
+			account: *account,
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported signer type %s", ttm.Signer.Type)
+	}
+
+	if s.Address() != sender {
+		return nil, fmt.Errorf("sender address mismatch %v != %v", sender, s.Address())
+	}
+
+	return s, nil
+}
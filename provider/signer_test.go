@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/0xPolygon/panoptichain/config"
+	"github.com/0xPolygon/panoptichain/remotesigner"
+)
+
+func newTestKey(t *testing.T) (*ecdsa.PrivateKey, common.Address) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	return key, crypto.PubkeyToAddress(key.PublicKey)
+}
+
+// newTestKeystore writes the key to an encrypted keystore file and the password
+// to a password file with a trailing newline, like most editors would.
+func newTestKeystore(t *testing.T, key *ecdsa.PrivateKey, password string) *config.Signer {
+	dir := t.TempDir()
+
+	ks := keystore.NewKeyStore(filepath.Join(dir, "keystore"), keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(key, password)
+	if err != nil {
+		t.Fatalf("failed to import key: %v", err)
+	}
+
+	passwordFile := filepath.Join(dir, "password")
+	if err := os.WriteFile(passwordFile, []byte(password+"\n"), 0600); err != nil {
+		t.Fatalf("failed to write password file: %v", err)
+	}
+
+	return &config.Signer{
+		Type:         "keystore",
+		Keystore:     account.URL.Path,
+		PasswordFile: passwordFile,
+	}
+}
+
+// newTestRemoteSigner starts the remote signer stand-in for the key.
+func newTestRemoteSigner(t *testing.T, key *ecdsa.PrivateKey) *config.Signer {
+	server, err := remotesigner.NewServer(key)
+	if err != nil {
+		t.Fatalf("failed to create remote signer: %v", err)
+	}
+	t.Cleanup(server.Stop)
+
+	s := httptest.NewServer(server)
+	t.Cleanup(s.Close)
+
+	return &config.Signer{Type: "external", URL: s.URL}
+}
+
+func TestSigner(t *testing.T) {
+	key, sender := newTestKey(t)
+
+	tests := []struct {
+		name string
+		ttm  *config.TimeToMine
+	}{
+		{
+			name: "private key",
+			ttm:  &config.TimeToMine{SenderPrivateKey: hex.EncodeToString(crypto.FromECDSA(key))},
+		},
+		{
+			name: "keystore",
+			ttm:  &config.TimeToMine{Signer: newTestKeystore(t, key, "password")},
+		},
+		{
+			name: "external",
+			ttm:  &config.TimeToMine{Signer: newTestRemoteSigner(t, key)},
+		},
+	}
+
+	chainID := big.NewInt(1337)
+	to := common.Address{1}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.ttm.Sender = sender.Hex()
+
+			s, err := newSigner(tt.ttm)
+			if err != nil {
+				t.Fatalf("failed to create signer: %v", err)
+			}
+
+			if s.Address() != sender {
+				t.Errorf("got address %v, want %v", s.Address(), sender)
+			}
+
+			for _, tx := range []*types.Transaction{
+				types.NewTx(&types.LegacyTx{Nonce: 1, To: &to, Value: big.NewInt(1), Gas: 21000, GasPrice: big.NewInt(1e9)}),
+				types.NewTx(&types.DynamicFeeTx{Nonce: 2, To: &to, Value: big.NewInt(1), Gas: 21000, GasTipCap: big.NewInt(1e9), GasFeeCap: big.NewInt(2e9)}),
+			} {
+				signed, err := s.SignTx(tx, chainID)
+				if err != nil {
+					t.Fatalf("failed to sign type %d transaction: %v", tx.Type(), err)
+				}
+
+				from, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+				if err != nil {
+					t.Fatalf("failed to recover type %d transaction sender: %v", tx.Type(), err)
+				}
+
+				if from != sender || signed.Nonce() != tx.Nonce() || signed.ChainId().Cmp(chainID) != 0 {
+					t.Errorf("got type %d transaction from %v with nonce %d and chain ID %v", tx.Type(), from, signed.Nonce(), signed.ChainId())
+				}
+			}
+		})
+	}
+}
+
+func TestSignerErrors(t *testing.T) {
+	key, sender := newTestKey(t)
+	_, other := newTestKey(t)
+
+	keystoreSigner := newTestKeystore(t, key, "password")
+	wrongPassword := *keystoreSigner
+	wrongPassword.PasswordFile = filepath.Join(t.TempDir(), "wrong")
+	if err := os.WriteFile(wrongPassword.PasswordFile, []byte("wrong"), 0600); err != nil {
+		t.Fatalf("failed to write password file: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		ttm  *config.TimeToMine
+		want string
+	}{
+		{
+			name: "invalid private key",
+			ttm:  &config.TimeToMine{Sender: sender.Hex(), SenderPrivateKey: "0x"},
+			want: "failed to parse sender private key",
+		},
+		{
+			name: "private key sender mismatch",
+			ttm:  &config.TimeToMine{Sender: other.Hex(), SenderPrivateKey: hex.EncodeToString(crypto.FromECDSA(key))},
+			want: "sender address mismatch",
+		},
+		{
+			name: "missing keystore",
+			ttm:  &config.TimeToMine{Sender: sender.Hex(), Signer: &config.Signer{Type: "keystore", Keystore: filepath.Join(t.TempDir(), "missing")}},
+			want: "failed to read keystore",
+		},
+		{
+			name: "wrong keystore password",
+			ttm:  &config.TimeToMine{Sender: sender.Hex(), Signer: &wrongPassword},
+			want: "failed to decrypt keystore",
+		},
+		{
+			name: "keystore sender mismatch",
+			ttm:  &config.TimeToMine{Sender: other.Hex(), Signer: keystoreSigner},
+			want: "sender address mismatch",
+		},
+		{
+			name: "external signer account not found",
+			ttm:  &config.TimeToMine{Sender: other.Hex(), Signer: newTestRemoteSigner(t, key)},
+			want: "is not an external signer account",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newSigner(tt.ttm)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
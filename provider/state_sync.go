@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+
+	"github.com/0xPolygon/panoptichain/network"
+	"github.com/0xPolygon/panoptichain/observer"
+	"github.com/0xPolygon/panoptichain/observer/topics"
+)
+
+// stateSyncRetention is how long a committed state sync is kept waiting for
+// its L1 event to be observed.
+const stateSyncRetention = 24 * time.Hour
+
+// networkStateSync is the state sync correlation state of a single Polygon PoS
+// network.
+type networkStateSync struct {
+	network network.Network
+
+	// pending maps the state IDs sent on L1 but not yet committed on Bor to the
+	// L1 block time.
+	pending map[uint64]time.Time
+
+	// committed maps the state IDs committed on Bor whose L1 event hasn't been
+	// observed yet to the Bor block time.
+	committed map[uint64]time.Time
+
+	latestSent      *uint64
+	latestCommitted *uint64
+	latencies       []time.Duration
+}
+
+// StateSyncProvider is a meta-provider that correlates the state syncs sent by
+// the StateSender on L1 with the state syncs committed by the StateReceiver on
+// Bor. Like the ValidatorScorecardProvider, it subscribes to the topics the RPC
+// providers publish to rather than querying them.
+//
+// See ../runner/runner.go to see how this provider is initialized.
+type StateSyncProvider struct {
+	bus              *observer.EventBus
+	interval         uint
+	label            string
+	logger           zerolog.Logger
+	stuckAfter       time.Duration
+	refreshStateTime *time.Duration
+
+	mu       sync.Mutex
+	networks map[string]*networkStateSync
+	messages []*observer.CoreMessage
+}
+
+// stateSyncSubscriber forwards the state sync events to the state sync
+// provider.
+type stateSyncSubscriber struct {
+	provider *StateSyncProvider
+}
+
+func (s *stateSyncSubscriber) Notify(ctx context.Context, m observer.Message) {
+	s.provider.notify(m)
+}
+
+func (s *stateSyncSubscriber) Register(eb *observer.EventBus) {
+	eb.Subscribe(topics.StateSyncEvents, s)
+}
+
+func (s *stateSyncSubscriber) GetCollectors() []prometheus.Collector {
+	return nil
+}
+
+func NewStateSyncProvider(eb *observer.EventBus, interval uint, stuckAfter time.Duration) *StateSyncProvider {
+	label := "state-sync"
+
+	p := &StateSyncProvider{
+		bus:              eb,
+		interval:         interval,
+		label:            label,
+		logger:           NewLogger(nil, label),
+		stuckAfter:       stuckAfter,
+		refreshStateTime: new(time.Duration),
+		networks:         make(map[string]*networkStateSync),
+	}
+
+	s := &stateSyncSubscriber{provider: p}
+	s.Register(eb)
+
+	return p
+}
+
+// notify correlates the state sync events by state ID. Multiple providers can
+// publish the same events, so state IDs that were already matched are ignored.
+func (p *StateSyncProvider) notify(m observer.Message) {
+	events := m.Data().(*observer.StateSyncEvents)
+	if events.Network == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ns, ok := p.networks[events.Network.GetName()]
+	if !ok {
+		ns = &networkStateSync{
+			network:   events.Network,
+			pending:   make(map[uint64]time.Time),
+			committed: make(map[uint64]time.Time),
+		}
+		p.networks[events.Network.GetName()] = ns
+	}
+
+	if id := events.LatestSentID; id != nil && (ns.latestSent == nil || *id > *ns.latestSent) {
+		ns.latestSent = id
+	}
+
+	for id, sent := range events.Sent {
+		if committed, ok := ns.committed[id]; ok {
+			ns.addLatency(committed.Sub(sent))
+			delete(ns.committed, id)
+			continue
+		}
+
+		if ns.latestCommitted != nil && id <= *ns.latestCommitted {
+			continue
+		}
+
+		ns.pending[id] = sent
+	}
+
+	for id, committed := range events.Committed {
+		if sent, ok := ns.pending[id]; ok {
+			ns.addLatency(committed.Sub(sent))
+			delete(ns.pending, id)
+			continue
+		}
+
+		ns.committed[id] = committed
+	}
+
+	if id := events.LatestCommittedID; id != nil && (ns.latestCommitted == nil || *id > *ns.latestCommitted) {
+		ns.latestCommitted = id
+
+		// State syncs can be committed without their event being observed,
+		// such as when the Bor provider was behind or down.
+		for pendingID := range ns.pending {
+			if pendingID <= *id {
+				delete(ns.pending, pendingID)
+			}
+		}
+	}
+}
+
+func (ns *networkStateSync) addLatency(latency time.Duration) {
+	if latency < 0 {
+		return
+	}
+
+	ns.latencies = append(ns.latencies, latency)
+}
+
+func (p *StateSyncProvider) RefreshState(ctx context.Context) error {
+	defer timer(p.refreshStateTime)()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = nil
+
+	for _, ns := range p.networks {
+		latency := &observer.StateSyncLatency{Latencies: ns.latencies}
+		ns.latencies = nil
+
+		if ns.latestSent != nil && ns.latestCommitted != nil && *ns.latestSent >= *ns.latestCommitted {
+			backlog := *ns.latestSent - *ns.latestCommitted
+			latency.Backlog = &backlog
+		}
+
+		for _, sent := range ns.pending {
+			latency.OldestPending = max(latency.OldestPending, time.Since(sent))
+		}
+		latency.Stuck = latency.OldestPending > p.stuckAfter
+
+		if latency.Stuck {
+			p.logger.Warn().
+				Str("network", ns.network.GetName()).
+				Dur("oldest_pending", latency.OldestPending).
+				Msg("State sync is stuck")
+		}
+
+		for id, committed := range ns.committed {
+			if time.Since(committed) > stateSyncRetention {
+				delete(ns.committed, id)
+			}
+		}
+
+		p.messages = append(p.messages, observer.NewMessage(ns.network, p.label, latency))
+	}
+
+	return nil
+}
+
+func (p *StateSyncProvider) PublishEvents(ctx context.Context) error {
+	for _, m := range p.messages {
+		p.bus.Publish(ctx, topics.StateSyncLatency, m)
+	}
+
+	p.bus.Publish(ctx, topics.RefreshStateTime, observer.NewMessage(nil, p.label, p.refreshStateTime))
+
+	return nil
+}
+
+func (p *StateSyncProvider) SetEventBus(bus *observer.EventBus) {
+	p.bus = bus
+}
+
+func (p *StateSyncProvider) PollingInterval() uint {
+	return p.interval
+}
@@ -0,0 +1,402 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/0xPolygon/panoptichain/observer"
+	"github.com/0xPolygon/panoptichain/observer/topics"
+)
+
+// defaultTimeToMineTimeout is how long to wait for a time to mine transaction
+// to be included before it is considered stuck.
+const defaultTimeToMineTimeout = 2 * time.Minute
+
+// timeToMineDroppedChecks is how many times in a row a time to mine transaction
+// must be unknown to the node before it is considered dropped. Load balanced
+// RPCs can briefly return not found for a transaction in another node's pool.
+const timeToMineDroppedChecks = 3
+
+// timeToMineProbe is a time to mine transaction that has been sent.
+type timeToMineProbe struct {
+	tx    *types.Transaction
+	start time.Time
+	// block is the latest block number when the transaction was sent.
+	block uint64
+	// stuck is set when the transaction wasn't included before the timeout.
+	stuck bool
+}
+
+// timeToMineState tracks the local nonce and the in-flight probe. Only one
+// probe is in flight at a time so new transactions never queue behind a
+// pending one. The state is shared with the goroutine waiting for the probe.
+type timeToMineState struct {
+	mu     sync.Mutex
+	signer signer
+	nonce  *uint64
+	probe  *timeToMineProbe
+}
+
+// refreshTimeToMine sends a transaction to the network and records the time it
+// took to be included in a block. If the previous transaction is stuck, it is
+// replaced with a transaction using the same nonce and bumped fees.
+func (r *RPCProvider) refreshTimeToMine(ctx context.Context, c *ethclient.Client) error {
+	if r.timeToMine == nil {
+		return nil
+	}
+
+	sender := common.HexToAddress(r.timeToMine.Sender)
+
+	chainID, err := c.ChainID(ctx)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to get network ID")
+		return err
+	}
+
+	suggestion, err := r.getGasPriceSuggestion(ctx, c)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to get suggested gas price")
+		return err
+	}
+	r.gasPriceSuggestions = append(r.gasPriceSuggestions, suggestion)
+
+	r.ttm.mu.Lock()
+	defer r.ttm.mu.Unlock()
+
+	// The signer is created lazily and cached because decrypting a keystore is
+	// expensive and an external signer may not be reachable at startup.
+	if r.ttm.signer == nil {
+		s, err := newSigner(r.timeToMine)
+		if err != nil {
+			r.logger.Error().Err(err).Msg("Failed to create time to mine signer")
+			return err
+		}
+		r.ttm.signer = s
+	}
+
+	prev := r.ttm.probe
+	if prev != nil && !prev.stuck {
+		r.logger.Debug().Msg("Time to mine transaction still pending")
+		return nil
+	}
+
+	if prev != nil {
+		// The stuck transaction may have been included after the timeout, in
+		// which case it doesn't need to be replaced.
+		nonce, err := c.NonceAt(ctx, sender, nil)
+		if err != nil {
+			r.logger.Error().Err(err).Msg("Failed to get nonce")
+			return err
+		}
+
+		if nonce > prev.tx.Nonce() {
+			r.publishTimeToMine(ctx, prev, observer.TimeToMineTimedOut, nil)
+			r.ttm.probe = nil
+			prev = nil
+		}
+	}
+
+	if r.ttm.nonce == nil {
+		nonce, err := c.PendingNonceAt(ctx, sender)
+		if err != nil {
+			r.logger.Error().Err(err).Msg("Failed to get pending nonce")
+			return err
+		}
+		r.ttm.nonce = &nonce
+	}
+
+	nonce := *r.ttm.nonce
+	var prevTx *types.Transaction
+	if prev != nil {
+		nonce = prev.tx.Nonce()
+		prevTx = prev.tx
+	}
+
+	tx, err := r.newTimeToMineTx(ctx, c, nonce, prevTx, suggestion.gasPrice)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to create transaction")
+		return err
+	}
+
+	signedTx, err := r.ttm.signer.SignTx(tx, chainID)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to sign transaction")
+		return err
+	}
+
+	if err = c.SendTransaction(ctx, signedTx); err != nil {
+		// Resync the nonce in case it was the cause of the failure.
+		if prev == nil {
+			r.ttm.nonce = nil
+		}
+
+		r.logger.Error().Err(err).Uint64("nonce", nonce).Msg("Failed to send transaction")
+		return err
+	}
+
+	if prev != nil {
+		r.logger.Info().
+			Uint64("nonce", nonce).
+			Str("replaced", prev.tx.Hash().Hex()).
+			Str("hash", signedTx.Hash().Hex()).
+			Msg("Replaced stuck time to mine transaction")
+		r.publishTimeToMine(ctx, prev, observer.TimeToMineReplaced, nil)
+	} else {
+		next := nonce + 1
+		r.ttm.nonce = &next
+	}
+
+	probe := &timeToMineProbe{
+		tx:    signedTx,
+		start: time.Now(),
+		block: r.BlockNumber,
+	}
+	r.ttm.probe = probe
+
+	// Generally, all messages sent to topics should be done in the PublishEvents
+	// method. This is the exception because of its asynchronous nature.
+	go r.waitForTimeToMine(ctx, c, probe)
+
+	return nil
+}
+
+// newTimeToMineTx creates an unsigned time to mine transaction. If prev is not
+// nil, the fees are bumped enough for the transaction to replace it. The
+// suggested gas price is used for legacy transactions.
+func (r *RPCProvider) newTimeToMineTx(ctx context.Context, c *ethclient.Client, nonce uint64, prev *types.Transaction, suggestedGasPrice *big.Int) (*types.Transaction, error) {
+	receiver := common.HexToAddress(r.timeToMine.Receiver)
+	value := big.NewInt(r.timeToMine.Value)
+	gasLimit := r.timeToMine.GasLimit
+	data := []byte(r.timeToMine.Data)
+	factor := big.NewInt(r.getGasPriceFactor())
+
+	if !r.timeToMine.DynamicFee {
+		gasPrice := new(big.Int).Mul(suggestedGasPrice, factor)
+
+		if prev != nil {
+			gasPrice = bigMax(gasPrice, bumpFee(prev.GasPrice()))
+		}
+
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    nonce,
+			To:       &receiver,
+			Value:    value,
+			Gas:      gasLimit,
+			GasPrice: gasPrice,
+			Data:     data,
+		}), nil
+	}
+
+	tip, err := r.getTimeToMineTip(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tip: %w", err)
+	}
+	tip.Mul(tip, factor)
+
+	header, err := c.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+
+	if header.BaseFee == nil {
+		return nil, errors.New("network does not support dynamic fee transactions")
+	}
+
+	feeCap := new(big.Int).Mul(header.BaseFee, big.NewInt(2))
+	feeCap.Add(feeCap, tip)
+
+	if prev != nil {
+		tip = bigMax(tip, bumpFee(prev.GasTipCap()))
+		feeCap = bigMax(feeCap, bumpFee(prev.GasFeeCap()))
+	}
+
+	return types.NewTx(&types.DynamicFeeTx{
+		Nonce:     nonce,
+		To:        &receiver,
+		Value:     value,
+		Gas:       gasLimit,
+		GasTipCap: tip,
+		GasFeeCap: feeCap,
+		Data:      data,
+	}), nil
+}
+
+// getTimeToMineTip returns the priority fee based on the tip strategy.
+func (r *RPCProvider) getTimeToMineTip(ctx context.Context, c *ethclient.Client) (*big.Int, error) {
+	switch r.timeToMine.TipStrategy {
+	case "fixed":
+		tip, _ := new(big.Float).Mul(big.NewFloat(r.timeToMine.TipCap), big.NewFloat(1e9)).Int(nil)
+		return tip, nil
+
+	case "fee_history":
+		percentile := r.timeToMine.TipPercentile
+		if percentile == 0 {
+			percentile = 50
+		}
+
+		history, err := c.FeeHistory(ctx, 20, nil, []float64{percentile})
+		if err != nil {
+			return nil, err
+		}
+
+		var rewards []*big.Int
+		for _, reward := range history.Reward {
+			if len(reward) > 0 {
+				rewards = append(rewards, reward[0])
+			}
+		}
+
+		if len(rewards) == 0 {
+			return nil, errors.New("fee history has no rewards")
+		}
+
+		slices.SortFunc(rewards, func(a, b *big.Int) int { return a.Cmp(b) })
+		return new(big.Int).Set(rewards[len(rewards)/2]), nil
+	}
+
+	return c.SuggestGasTipCap(ctx)
+}
+
+// waitForTimeToMine polls for the probe's receipt until it is included or
+// dropped from the mempool, then publishes the outcome. If it times out, the
+// probe is marked as stuck instead.
+func (r *RPCProvider) waitForTimeToMine(ctx context.Context, c *ethclient.Client, probe *timeToMineProbe) {
+	timeout := defaultTimeToMineTimeout
+	if r.timeToMine.Timeout > 0 {
+		timeout = time.Duration(r.timeToMine.Timeout) * time.Second
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	hash := probe.tx.Hash()
+	sender := common.HexToAddress(r.timeToMine.Sender)
+	var notFound int
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			// The outcome of a stuck probe isn't published until the next refresh
+			// either replaces it or finds it was included after the timeout.
+			r.ttm.mu.Lock()
+			if r.ttm.probe == probe {
+				probe.stuck = true
+			}
+			r.ttm.mu.Unlock()
+			return
+		case <-ticker.C:
+		}
+
+		receipt, err := c.TransactionReceipt(ctx, hash)
+		if err == nil {
+			r.finishTimeToMine(ctx, probe, observer.TimeToMineIncluded, receipt)
+			return
+		}
+
+		if !errors.Is(err, ethereum.NotFound) {
+			r.logger.Trace().Err(err).Msg("Failed to get time to mine transaction receipt")
+			continue
+		}
+
+		if _, _, err = c.TransactionByHash(ctx, hash); !errors.Is(err, ethereum.NotFound) {
+			notFound = 0
+			continue
+		}
+
+		notFound++
+		if notFound < timeToMineDroppedChecks {
+			continue
+		}
+
+		// The transaction is neither included nor known to the node, and the
+		// pending nonce confirms it isn't in the mempool, so it was evicted.
+		nonce, err := c.PendingNonceAt(ctx, sender)
+		if err != nil {
+			r.logger.Trace().Err(err).Msg("Failed to get pending nonce")
+			continue
+		}
+
+		if nonce <= probe.tx.Nonce() {
+			r.finishTimeToMine(ctx, probe, observer.TimeToMineDropped, nil)
+			return
+		}
+	}
+}
+
+// finishTimeToMine updates the shared state with the outcome of the probe and
+// publishes it.
+func (r *RPCProvider) finishTimeToMine(ctx context.Context, probe *timeToMineProbe, status string, receipt *types.Receipt) {
+	r.ttm.mu.Lock()
+	if r.ttm.probe == probe {
+		switch status {
+		case observer.TimeToMineDropped:
+			// The nonce of a dropped transaction is unused, so resync it.
+			r.ttm.probe = nil
+			r.ttm.nonce = nil
+		default:
+			r.ttm.probe = nil
+		}
+	}
+	r.ttm.mu.Unlock()
+
+	r.publishTimeToMine(ctx, probe, status, receipt)
+}
+
+func (r *RPCProvider) publishTimeToMine(ctx context.Context, probe *timeToMineProbe, status string, receipt *types.Receipt) {
+	ttm := &observer.TimeToMine{
+		Status:         status,
+		Seconds:        time.Since(probe.start).Seconds(),
+		GasPrice:       probe.tx.GasFeeCap(),
+		GasPriceFactor: r.getGasPriceFactor(),
+		DynamicFee:     probe.tx.Type() == types.DynamicFeeTxType,
+	}
+
+	if receipt != nil {
+		ttm.GasPrice = receipt.EffectiveGasPrice
+		if receipt.BlockNumber != nil && receipt.BlockNumber.Uint64() > probe.block {
+			ttm.Blocks = receipt.BlockNumber.Uint64() - probe.block
+		}
+	}
+
+	m := observer.NewMessage(r.Network, r.Label, ttm)
+	r.bus.Publish(ctx, topics.TimeToMine, m)
+}
+
+func (r *RPCProvider) getGasPriceFactor() int64 {
+	if r.timeToMine.GasPriceFactor == 0 {
+		return 1
+	}
+
+	return r.timeToMine.GasPriceFactor
+}
+
+// bumpFee increases the fee by just over 10%, the minimum price bump most
+// clients require to replace a pending transaction.
+func bumpFee(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(11))
+	bumped.Div(bumped, big.NewInt(10))
+	return bumped.Add(bumped, big.NewInt(1))
+}
+
+func bigMax(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,463 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+
+	"github.com/0xPolygon/panoptichain/api"
+	"github.com/0xPolygon/panoptichain/network"
+	"github.com/0xPolygon/panoptichain/observer"
+	"github.com/0xPolygon/panoptichain/observer/topics"
+)
+
+// scorecardBuckets is the number of buckets the rolling window is split into.
+const scorecardBuckets = 60
+
+// The proposal types tracked by the validator scorecard.
+const (
+	proposalBor        = "bor"
+	proposalHeimdall   = "heimdall"
+	proposalCheckpoint = "checkpoint"
+	proposalMilestone  = "milestone"
+)
+
+// validatorCounts is the participation of a single validator within a bucket.
+type validatorCounts struct {
+	signed    uint64
+	proposals map[string]*observer.ValidatorProposals
+}
+
+func (v *validatorCounts) proposal(kind string) *observer.ValidatorProposals {
+	p, ok := v.proposals[kind]
+	if !ok {
+		p = &observer.ValidatorProposals{}
+		v.proposals[kind] = p
+	}
+
+	return p
+}
+
+// scorecardBucket holds the participation of all validators over a slice of
+// the rolling window.
+type scorecardBucket struct {
+	start          time.Time
+	heimdallBlocks uint64
+	validators     map[string]*validatorCounts
+}
+
+func (b *scorecardBucket) validator(signer string) *validatorCounts {
+	signer = normalizeSigner(signer)
+
+	v, ok := b.validators[signer]
+	if !ok {
+		v = &validatorCounts{proposals: make(map[string]*observer.ValidatorProposals)}
+		b.validators[signer] = v
+	}
+
+	return v
+}
+
+// networkScorecard is the rolling window state of a single network. Because
+// multiple providers can publish the same data for a network, block based
+// events are deduplicated using the highest number processed, and list based
+// events are only accepted from the first provider that published them.
+type networkScorecard struct {
+	network network.Network
+	buckets []*scorecardBucket
+
+	borBlock            uint64
+	borMissedBlock      uint64
+	heimdallBlock       uint64
+	heimdallMissedBlock uint64
+	checkpointID        *int64
+	milestoneCount      *int64
+	sources             map[topics.ObservableTopic]string
+}
+
+// ValidatorScorecardProvider is a meta-provider that aggregates the Bor and
+// Heimdall participation of each validator over a rolling window. Rather than
+// querying other providers, it subscribes to the topics they publish to. The
+// scorecards are published to the ValidatorScorecards topic and served as JSON.
+//
+// See ../runner/runner.go to see how this provider is initialized.
+type ValidatorScorecardProvider struct {
+	bus              *observer.EventBus
+	interval         uint
+	label            string
+	logger           zerolog.Logger
+	window           time.Duration
+	refreshStateTime *time.Duration
+
+	mu         sync.Mutex
+	networks   map[string]*networkScorecard
+	scorecards map[string]*observer.ValidatorScorecards
+	messages   []*observer.CoreMessage
+}
+
+// scorecardSubscriber forwards the messages of a single topic to the validator
+// scorecard provider. The topic is needed because some topics share the same
+// message data type.
+type scorecardSubscriber struct {
+	provider *ValidatorScorecardProvider
+	topic    topics.ObservableTopic
+}
+
+func (s *scorecardSubscriber) Notify(ctx context.Context, m observer.Message) {
+	s.provider.notify(s.topic, m)
+}
+
+func (s *scorecardSubscriber) Register(eb *observer.EventBus) {
+	eb.Subscribe(s.topic, s)
+}
+
+func (s *scorecardSubscriber) GetCollectors() []prometheus.Collector {
+	return nil
+}
+
+func NewValidatorScorecardProvider(eb *observer.EventBus, interval uint, window time.Duration) *ValidatorScorecardProvider {
+	label := "validator-scorecard"
+
+	p := &ValidatorScorecardProvider{
+		bus:              eb,
+		interval:         interval,
+		label:            label,
+		logger:           NewLogger(nil, label),
+		window:           window,
+		refreshStateTime: new(time.Duration),
+		networks:         make(map[string]*networkScorecard),
+		scorecards:       make(map[string]*observer.ValidatorScorecards),
+	}
+
+	for _, topic := range []topics.ObservableTopic{
+		topics.NewEVMBlock,
+		topics.BorMissedBlockProposal,
+		topics.NewHeimdallBlock,
+		topics.HeimdallMissedBlockProposal,
+		topics.Checkpoint,
+		topics.MissedCheckpointProposal,
+		topics.Milestone,
+		topics.MissedMilestoneProposal,
+	} {
+		s := &scorecardSubscriber{provider: p, topic: topic}
+		s.Register(eb)
+	}
+
+	return p
+}
+
+// notify records the participation data in a message.
+func (p *ValidatorScorecardProvider) notify(topic topics.ObservableTopic, m observer.Message) {
+	if m.Network() == nil || !m.Network().IsPolygonPoS() {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ns := p.getNetworkScorecard(m.Network())
+	bucket := ns.getBucket(m.Time(), p.window)
+
+	switch data := m.Data().(type) {
+	case *types.Block:
+		if data.NumberU64() <= ns.borBlock {
+			return
+		}
+		ns.borBlock = data.NumberU64()
+
+		// The block is deduplicated first so that the expensive Ecrecover is only
+		// done once per block rather than once per provider.
+		signer, err := api.Ecrecover(data.Header())
+		if err != nil {
+			p.logger.Debug().Err(err).Msg("Failed to get block signer")
+			return
+		}
+
+		author := "0x" + hex.EncodeToString(signer)
+		bucket.validator(author).proposal(proposalBor).Made++
+
+	case observer.MissedBlockProposal:
+		ns.borMissedBlock = countMissed(bucket, proposalBor, data, ns.borMissedBlock)
+
+	case *observer.HeimdallBlock:
+		n := data.Number()
+		if n == nil || n.Uint64() <= ns.heimdallBlock {
+			return
+		}
+		ns.heimdallBlock = n.Uint64()
+
+		bucket.heimdallBlocks++
+		bucket.validator(data.ProposerAddress()).proposal(proposalHeimdall).Made++
+
+		for _, precommit := range data.PreCommits() {
+			if precommit == nil {
+				continue
+			}
+			bucket.validator(precommit.ValidatorAddress).signed++
+		}
+
+	case observer.HeimdallMissedBlockProposal:
+		ns.heimdallMissedBlock = countMissed(bucket, proposalHeimdall, data, ns.heimdallMissedBlock)
+
+	case *observer.HeimdallCheckpoint:
+		id, err := data.ID.Int64()
+		if err != nil {
+			return
+		}
+
+		// The first checkpoint seen may have been proposed before the window.
+		if ns.checkpointID != nil && id > *ns.checkpointID {
+			bucket.validator(data.Proposer).proposal(proposalCheckpoint).Made++
+		}
+		if ns.checkpointID == nil || id > *ns.checkpointID {
+			ns.checkpointID = &id
+		}
+
+	case *observer.HeimdallMilestone:
+		if ns.milestoneCount != nil && data.Count > *ns.milestoneCount {
+			bucket.validator(data.Proposer).proposal(proposalMilestone).Made++
+		}
+		if ns.milestoneCount == nil || data.Count > *ns.milestoneCount {
+			count := data.Count
+			ns.milestoneCount = &count
+		}
+
+	case []string:
+		if source, ok := ns.sources[topic]; ok && source != m.Provider() {
+			return
+		}
+		ns.sources[topic] = m.Provider()
+
+		kind := proposalCheckpoint
+		if topic == topics.MissedMilestoneProposal {
+			kind = proposalMilestone
+		}
+
+		for _, signer := range data {
+			bucket.validator(signer).proposal(kind).Missed++
+		}
+	}
+}
+
+// countMissed records the missed proposals of blocks after the last block that
+// was processed and returns the new last block.
+func countMissed(bucket *scorecardBucket, kind string, missed map[uint64][]string, last uint64) uint64 {
+	next := last
+	for number, signers := range missed {
+		if number <= last {
+			continue
+		}
+
+		for _, signer := range signers {
+			bucket.validator(signer).proposal(kind).Missed++
+		}
+
+		next = max(next, number)
+	}
+
+	return next
+}
+
+func (p *ValidatorScorecardProvider) getNetworkScorecard(n network.Network) *networkScorecard {
+	ns, ok := p.networks[n.GetName()]
+	if !ok {
+		ns = &networkScorecard{
+			network: n,
+			sources: make(map[topics.ObservableTopic]string),
+		}
+		p.networks[n.GetName()] = ns
+	}
+
+	return ns
+}
+
+// getBucket returns the bucket for the given time, creating a new one if the
+// latest bucket has ended.
+func (ns *networkScorecard) getBucket(t time.Time, window time.Duration) *scorecardBucket {
+	if len(ns.buckets) > 0 {
+		latest := ns.buckets[len(ns.buckets)-1]
+		if t.Sub(latest.start) < window/scorecardBuckets {
+			return latest
+		}
+	}
+
+	bucket := &scorecardBucket{
+		start:      t,
+		validators: make(map[string]*validatorCounts),
+	}
+	ns.buckets = append(ns.buckets, bucket)
+
+	return bucket
+}
+
+// aggregate drops the buckets outside the window and sums the rest.
+func (ns *networkScorecard) aggregate(window time.Duration) (uint64, map[string]*validatorCounts) {
+	cutoff := time.Now().Add(-window)
+	for len(ns.buckets) > 0 && ns.buckets[0].start.Before(cutoff) {
+		ns.buckets = ns.buckets[1:]
+	}
+
+	var heimdallBlocks uint64
+	totals := make(map[string]*validatorCounts)
+
+	for _, bucket := range ns.buckets {
+		heimdallBlocks += bucket.heimdallBlocks
+
+		for signer, counts := range bucket.validators {
+			total, ok := totals[signer]
+			if !ok {
+				total = &validatorCounts{proposals: make(map[string]*observer.ValidatorProposals)}
+				totals[signer] = total
+			}
+
+			total.signed += counts.signed
+			for kind, proposals := range counts.proposals {
+				total.proposal(kind).Made += proposals.Made
+				total.proposal(kind).Missed += proposals.Missed
+			}
+		}
+	}
+
+	return heimdallBlocks, totals
+}
+
+func (p *ValidatorScorecardProvider) RefreshState(ctx context.Context) error {
+	defer timer(p.refreshStateTime)()
+
+	type snapshot struct {
+		network        network.Network
+		heimdallBlocks uint64
+		totals         map[string]*validatorCounts
+	}
+
+	p.mu.Lock()
+	snapshots := make([]snapshot, 0, len(p.networks))
+	for _, ns := range p.networks {
+		heimdallBlocks, totals := ns.aggregate(p.window)
+		snapshots = append(snapshots, snapshot{ns.network, heimdallBlocks, totals})
+	}
+	p.mu.Unlock()
+
+	scorecards := make(map[string]*observer.ValidatorScorecards)
+	p.messages = nil
+
+	for _, s := range snapshots {
+		validators, err := api.Validators(s.network)
+		if err != nil {
+			p.logger.Error().Err(err).Str("network", s.network.GetName()).Msg("Failed to get validators")
+			continue
+		}
+
+		sc := &observer.ValidatorScorecards{
+			WindowSeconds:  p.window.Seconds(),
+			HeimdallBlocks: s.heimdallBlocks,
+		}
+
+		for _, validator := range validators {
+			signer := normalizeSigner(validator.GetSigner())
+
+			card := &observer.ValidatorScorecard{
+				ID:        validator.GetID(),
+				Signer:    signer,
+				Power:     validator.GetPower(),
+				Jailed:    validator.IsJailed(),
+				Proposals: make(map[string]observer.ValidatorProposals),
+			}
+
+			for _, kind := range []string{proposalBor, proposalHeimdall, proposalCheckpoint, proposalMilestone} {
+				card.Proposals[kind] = observer.ValidatorProposals{}
+			}
+
+			if counts, ok := s.totals[signer]; ok {
+				card.SignedBlocks = counts.signed
+				for kind, proposals := range counts.proposals {
+					card.Proposals[kind] = *proposals
+				}
+			}
+
+			if s.heimdallBlocks > 0 {
+				card.Uptime = float64(card.SignedBlocks) / float64(s.heimdallBlocks) * 100
+			}
+
+			sc.Validators = append(sc.Validators, card)
+		}
+
+		sort.Slice(sc.Validators, func(i, j int) bool {
+			return sc.Validators[i].ID < sc.Validators[j].ID
+		})
+
+		scorecards[s.network.GetName()] = sc
+		p.messages = append(p.messages, observer.NewMessage(s.network, p.label, sc))
+	}
+
+	p.mu.Lock()
+	p.scorecards = scorecards
+	p.mu.Unlock()
+
+	return nil
+}
+
+func (p *ValidatorScorecardProvider) PublishEvents(ctx context.Context) error {
+	for _, m := range p.messages {
+		p.bus.Publish(ctx, topics.ValidatorScorecards, m)
+	}
+
+	p.bus.Publish(ctx, topics.RefreshStateTime, observer.NewMessage(nil, p.label, p.refreshStateTime))
+
+	return nil
+}
+
+func (p *ValidatorScorecardProvider) SetEventBus(bus *observer.EventBus) {
+	p.bus = bus
+}
+
+func (p *ValidatorScorecardProvider) PollingInterval() uint {
+	return p.interval
+}
+
+// ServeHTTP responds with the latest scorecards keyed by network name. The
+// optional "network" query parameter filters the response to a single network.
+func (p *ValidatorScorecardProvider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	var response any = p.scorecards
+	if name := r.URL.Query().Get("network"); name != "" {
+		sc, ok := p.scorecards[name]
+		if !ok {
+			p.mu.Unlock()
+			http.Error(w, "unknown network "+strconv.Quote(name), http.StatusNotFound)
+			return
+		}
+		response = sc
+	}
+	body, err := json.Marshal(response)
+	p.mu.Unlock()
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// normalizeSigner converts Bor (0x-prefixed) and Tendermint (uppercase, no
+// prefix) validator addresses to the same format as api.Validator signers.
+func normalizeSigner(signer string) string {
+	signer = strings.ToLower(signer)
+	if !strings.HasPrefix(signer, "0x") {
+		signer = "0x" + signer
+	}
+
+	return signer
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/0xPolygon/panoptichain/api"
+	"github.com/0xPolygon/panoptichain/network"
+	"github.com/0xPolygon/panoptichain/observer"
+	"github.com/0xPolygon/panoptichain/observer/topics"
+)
+
+// ValidatorSetProvider polls the Heimdall validator set of each Polygon PoS
+// network and diffs it against the previous snapshot to detect validators
+// joining, exiting, being jailed or unjailed, changing power, rotating their
+// signer, and changing their nonce. Fetching the validator set also refreshes
+// the validator cache, so signer rotations are picked up by other observers
+// without waiting for the cache to expire.
+//
+// See ../runner/runner.go to see how this provider is initialized.
+type ValidatorSetProvider struct {
+	bus              *observer.EventBus
+	interval         uint
+	label            string
+	logger           zerolog.Logger
+	networks         []network.Network
+	refreshStateTime *time.Duration
+
+	// snapshots maps the network name to the validators keyed by ID.
+	snapshots map[string]map[uint64]api.Validator
+	sets      []*observer.CoreMessage
+	changes   []*observer.CoreMessage
+}
+
+func NewValidatorSetProvider(networks []network.Network, eb *observer.EventBus, interval uint) *ValidatorSetProvider {
+	label := "validator-set"
+
+	return &ValidatorSetProvider{
+		bus:              eb,
+		interval:         interval,
+		label:            label,
+		logger:           NewLogger(nil, label),
+		networks:         networks,
+		refreshStateTime: new(time.Duration),
+		snapshots:        make(map[string]map[uint64]api.Validator),
+	}
+}
+
+func (p *ValidatorSetProvider) RefreshState(ctx context.Context) error {
+	defer timer(p.refreshStateTime)()
+
+	p.sets = nil
+	p.changes = nil
+
+	for _, n := range p.networks {
+		validators, err := api.FetchValidators(n)
+		if err != nil {
+			p.logger.Error().Err(err).Str("network", n.GetName()).Msg("Failed to get validators")
+			continue
+		}
+
+		set := &observer.ValidatorSet{Size: len(validators)}
+		snapshot := make(map[uint64]api.Validator, len(validators))
+
+		for _, v := range validators {
+			snapshot[v.GetID()] = v
+			set.TotalStake += v.GetPower()
+
+			switch {
+			case v.IsJailed():
+				set.Jailed++
+			case v.GetPower() > 0:
+				set.Active++
+			}
+		}
+
+		p.sets = append(p.sets, observer.NewMessage(n, p.label, set))
+
+		// The first snapshot is only used as the baseline.
+		if prev, ok := p.snapshots[n.GetName()]; ok {
+			changes := diffValidatorSets(prev, snapshot)
+			for _, change := range changes {
+				p.logger.Info().
+					Str("network", n.GetName()).
+					Str("type", change.Type).
+					Uint64("validator_id", change.Validator.GetID()).
+					Str("signer", change.Validator.GetSigner()).
+					Msg("Validator set changed")
+			}
+
+			if len(changes) > 0 {
+				p.changes = append(p.changes, observer.NewMessage(n, p.label, changes))
+			}
+		}
+
+		p.snapshots[n.GetName()] = snapshot
+	}
+
+	return nil
+}
+
+// diffValidatorSets returns the changes between the previous and the current
+// validator sets. A single validator can have multiple changes.
+func diffValidatorSets(prev, curr map[uint64]api.Validator) observer.ValidatorSetChanges {
+	var changes observer.ValidatorSetChanges
+
+	add := func(kind string, v, p api.Validator) {
+		changes = append(changes, observer.ValidatorSetChange{Type: kind, Validator: v, Previous: p})
+	}
+
+	for id, v := range curr {
+		p, ok := prev[id]
+		if !ok {
+			add(observer.ValidatorJoined, v, nil)
+			continue
+		}
+
+		if v.IsJailed() && !p.IsJailed() {
+			add(observer.ValidatorJailed, v, p)
+		}
+		if !v.IsJailed() && p.IsJailed() {
+			add(observer.ValidatorUnjailed, v, p)
+		}
+		if v.GetPower() != p.GetPower() {
+			add(observer.ValidatorPowerChanged, v, p)
+		}
+		if !strings.EqualFold(v.GetSigner(), p.GetSigner()) {
+			add(observer.ValidatorSignerRotated, v, p)
+		}
+		if v.GetNonce() != p.GetNonce() {
+			add(observer.ValidatorNonceChanged, v, p)
+		}
+	}
+
+	for id, p := range prev {
+		if _, ok := curr[id]; !ok {
+			add(observer.ValidatorExited, p, p)
+		}
+	}
+
+	return changes
+}
+
+func (p *ValidatorSetProvider) PublishEvents(ctx context.Context) error {
+	for _, m := range p.sets {
+		p.bus.Publish(ctx, topics.ValidatorSet, m)
+	}
+
+	for _, m := range p.changes {
+		p.bus.Publish(ctx, topics.ValidatorSetChanges, m)
+	}
+
+	p.bus.Publish(ctx, topics.RefreshStateTime, observer.NewMessage(nil, p.label, p.refreshStateTime))
+
+	return nil
+}
+
+func (p *ValidatorSetProvider) SetEventBus(bus *observer.EventBus) {
+	p.bus = bus
+}
+
+func (p *ValidatorSetProvider) PollingInterval() uint {
+	return p.interval
+}
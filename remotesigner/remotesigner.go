@@ -0,0 +1,74 @@
+// Package remotesigner is a minimal stand-in for a Clef compatible external
+// signer. It holds a single key and signs every transaction it receives without
+// confirmation, so it must only be used for local testing of the time to mine
+// external signer.
+package remotesigner
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"log/slog"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// signTransactionResult matches the result of Clef's account_signTransaction.
+type signTransactionResult struct {
+	Raw hexutil.Bytes      `json:"raw"`
+	Tx  *types.Transaction `json:"tx"`
+}
+
+// accountAPI implements the subset of the Clef "account" namespace used by
+// go-ethereum's external signer.
+type accountAPI struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+func (a *accountAPI) Version() string {
+	return "6.0.0"
+}
+
+func (a *accountAPI) List() []common.Address {
+	return []common.Address{a.address}
+}
+
+func (a *accountAPI) SignTransaction(args apitypes.SendTxArgs, methodSelector *string) (*signTransactionResult, error) {
+	if args.From.Address() != a.address {
+		return nil, errors.New("unknown account")
+	}
+
+	if args.ChainID == nil {
+		return nil, errors.New("chain ID is required")
+	}
+
+	tx, err := types.SignTx(args.ToTransaction(), types.LatestSignerForChainID(args.ChainID.ToInt()), a.key)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Signed transaction", "hash", tx.Hash(), "nonce", tx.Nonce())
+	return &signTransactionResult{Raw: raw, Tx: tx}, nil
+}
+
+// NewServer creates a JSON-RPC server that signs transactions with the key.
+func NewServer(key *ecdsa.PrivateKey) (*rpc.Server, error) {
+	api := &accountAPI{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", api); err != nil {
+		return nil, err
+	}
+
+	return server, nil
+}
@@ -0,0 +1,144 @@
+package runner
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/panoptichain/log"
+	"github.com/0xPolygon/panoptichain/network"
+	"github.com/0xPolygon/panoptichain/provider"
+	"github.com/0xPolygon/panoptichain/util"
+)
+
+// runningProvider is a provider whose loop is running.
+type runningProvider struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// manager runs the providers. Providers configured at startup run until the
+// context is done, and providers that are started while running, such as the
+// trusted sequencer providers, run until they're stopped.
+type manager struct {
+	mu        sync.Mutex
+	wg        sync.WaitGroup
+	ctx       context.Context
+	providers []provider.Provider
+	running   map[provider.Provider]*runningProvider
+
+	// hashDivergence also compares the blocks of the RPC providers started
+	// while running.
+	hashDivergence *provider.HashDivergenceProvider
+}
+
+func newManager() *manager {
+	return &manager{
+		running: make(map[provider.Provider]*runningProvider),
+	}
+}
+
+// add adds a provider that's run when the manager is started.
+func (m *manager) add(p provider.Provider) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.providers = append(m.providers, p)
+}
+
+// run starts every provider and blocks until they have all returned.
+func (m *manager) run(ctx context.Context) {
+	m.mu.Lock()
+	m.ctx = ctx
+	for _, p := range m.providers {
+		m.start(p)
+	}
+	m.mu.Unlock()
+
+	m.wg.Wait()
+}
+
+// start runs the provider's loop until its context is done. The lock must be
+// held.
+func (m *manager) start(p provider.Provider) {
+	ctx, cancel := context.WithCancel(m.ctx)
+	rp := &runningProvider{cancel: cancel, done: make(chan struct{})}
+	m.running[p] = rp
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		defer close(rp.done)
+
+		for ctx.Err() == nil {
+			if err := p.RefreshState(ctx); err != nil {
+				log.Error().Err(err).Send()
+			}
+
+			if err := p.PublishEvents(ctx); err != nil {
+				log.Error().Err(err).Send()
+			}
+
+			util.BlockFor(ctx, time.Second*time.Duration(p.PollingInterval()))
+		}
+	}()
+}
+
+// Start runs a provider while the manager is running.
+func (m *manager) Start(p provider.Provider) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.ctx == nil {
+		log.Error().Msg("Failed to start provider before the runner started")
+		return
+	}
+
+	if _, ok := m.running[p]; ok {
+		return
+	}
+
+	m.providers = append(m.providers, p)
+	m.start(p)
+
+	if rpc, ok := p.(*provider.RPCProvider); ok && m.hashDivergence != nil {
+		m.hashDivergence.AddProvider(rpc)
+	}
+}
+
+// Stop stops a provider and waits for its loop to return.
+func (m *manager) Stop(p provider.Provider) {
+	m.mu.Lock()
+	rp, ok := m.running[p]
+	delete(m.running, p)
+	m.providers = slices.DeleteFunc(m.providers, func(provider provider.Provider) bool {
+		return provider == p
+	})
+	m.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if rpc, ok := p.(*provider.RPCProvider); ok && m.hashDivergence != nil {
+		m.hashDivergence.RemoveProvider(rpc)
+	}
+
+	rp.cancel()
+	<-rp.done
+}
+
+// RemoveNetwork removes the network from every provider that keeps state for
+// other providers' networks.
+func (m *manager) RemoveNetwork(n network.Network) {
+	m.mu.Lock()
+	providers := slices.Clone(m.providers)
+	m.mu.Unlock()
+
+	for _, p := range providers {
+		if remover, ok := p.(provider.NetworkRemover); ok {
+			remover.RemoveNetwork(n)
+		}
+	}
+}
@@ -3,7 +3,8 @@ package runner
 
 import (
 	"context"
-	"sync"
+	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/0xPolygon/panoptichain/config"
@@ -11,46 +12,40 @@ import (
 	"github.com/0xPolygon/panoptichain/network"
 	"github.com/0xPolygon/panoptichain/observer"
 	"github.com/0xPolygon/panoptichain/provider"
-	"github.com/0xPolygon/panoptichain/util"
 )
 
 var providers []provider.Provider
 var observers observer.ObserverSet
+var mgr *manager
 
-// Start starts the main infinite loop of this program.
+// Start starts the main loop of this program, which runs until the context is
+// done.
 func Start(ctx context.Context) {
 	log.Info().Msg("Starting main loop")
 
-	var wg sync.WaitGroup
-	wg.Add(len(providers))
-
-	for _, p := range providers {
-		go func(p provider.Provider) {
-			defer wg.Done()
-
-			for {
-				if err := p.RefreshState(ctx); err != nil {
-					log.Error().Err(err).Send()
-				}
-
-				if err := p.PublishEvents(ctx); err != nil {
-					log.Error().Err(err).Send()
-				}
-
-				util.BlockFor(ctx, time.Second*time.Duration(p.PollingInterval()))
-			}
-		}(p)
-	}
-
-	wg.Wait()
+	mgr.run(ctx)
 }
 
 // Init configures all the providers and observers of the system.
 func Init(ctx context.Context) error {
 	providers = make([]provider.Provider, 0)
+	mgr = newManager()
 
 	eb := observer.NewEventBus()
 
+	// The exchange rates provider is initialized first because other providers
+	// use its rates for conversions.
+	var exchangeRates *provider.ExchangeRatesProvider
+	if er := config.Config().Providers.ExchangeRates; er != nil {
+		interval := config.Config().Runner.Interval
+		if er.Interval > 0 {
+			interval = er.Interval
+		}
+
+		exchangeRates = provider.NewExchangeRatesProvider(er.CoinbaseURL, er.Tokens, eb, interval)
+		providers = append(providers, exchangeRates)
+	}
+
 	var rpcProviders []*provider.RPCProvider
 	for _, r := range config.Config().Providers.RPCs {
 		n, err := network.GetNetworkByName(r.Name)
@@ -69,6 +64,14 @@ func Init(ctx context.Context) error {
 			blockLookBack = *r.BlockLookBack
 		}
 
+		var checkpointNetwork network.Network
+		if len(r.CheckpointNetwork) > 0 {
+			checkpointNetwork, err = network.GetNetworkByName(r.CheckpointNetwork)
+			if err != nil {
+				return err
+			}
+		}
+
 		p := provider.NewRPCProvider(provider.RPCProviderOpts{
 			Network:       n,
 			URL:           r.URL,
@@ -79,6 +82,20 @@ func Init(ctx context.Context) error {
 			TimeToMine:    r.TimeToMine,
 			Accounts:      r.Accounts,
 			BlockLookBack: blockLookBack,
+			Calls:         r.Calls,
+			TokenBalances: r.TokenBalances,
+			BridgeTokens:  r.BridgeTokens,
+			Rollups:       r.Rollups,
+			ExchangeRates: exchangeRates,
+			Manager:       mgr,
+
+			CheckpointNetwork: checkpointNetwork,
+			OPStack:           r.OPStack,
+
+			StateSync:            config.Config().Providers.StateSync != nil,
+			BridgeReconciliation: config.Config().Providers.BridgeReconciliation != nil,
+			ExitRootConsistency:  config.Config().Providers.ExitRootConsistency != nil,
+			BatchPipeline:        config.Config().Providers.BatchPipeline != nil,
 		})
 
 		providers = append(providers, p)
@@ -93,6 +110,113 @@ func Init(ctx context.Context) error {
 
 		p := provider.NewHashDivergenceProvider(rpcProviders, eb, interval)
 		providers = append(providers, p)
+		mgr.hashDivergence = p
+	}
+
+	if vs := config.Config().Providers.ValidatorScorecard; vs != nil {
+		interval := config.Config().Runner.Interval
+		if vs.Interval > 0 {
+			interval = vs.Interval
+		}
+
+		window := time.Hour
+		if vs.Window > 0 {
+			window = time.Duration(vs.Window) * time.Second
+		}
+
+		path := "/validators"
+		if len(vs.Path) > 0 {
+			path = vs.Path
+		}
+
+		p := provider.NewValidatorScorecardProvider(eb, interval, window)
+		providers = append(providers, p)
+
+		// The scorecards are served alongside the Prometheus metrics.
+		http.Handle(path, p)
+	}
+
+	if ss := config.Config().Providers.StateSync; ss != nil {
+		interval := config.Config().Runner.Interval
+		if ss.Interval > 0 {
+			interval = ss.Interval
+		}
+
+		stuckAfter := time.Hour
+		if ss.StuckAfter > 0 {
+			stuckAfter = time.Duration(ss.StuckAfter) * time.Second
+		}
+
+		p := provider.NewStateSyncProvider(eb, interval, stuckAfter)
+		providers = append(providers, p)
+	}
+
+	if br := config.Config().Providers.BridgeReconciliation; br != nil {
+		interval := config.Config().Runner.Interval
+		if br.Interval > 0 {
+			interval = br.Interval
+		}
+
+		thresholds := []time.Duration{time.Hour, 24 * time.Hour}
+		if len(br.UnclaimedThresholds) > 0 {
+			thresholds = nil
+			for _, t := range br.UnclaimedThresholds {
+				thresholds = append(thresholds, time.Duration(t)*time.Second)
+			}
+		}
+
+		p := provider.NewBridgeReconciliationProvider(eb, interval, thresholds)
+		providers = append(providers, p)
+	}
+
+	if erc := config.Config().Providers.ExitRootConsistency; erc != nil {
+		interval := config.Config().Runner.Interval
+		if erc.Interval > 0 {
+			interval = erc.Interval
+		}
+
+		sla := 30 * time.Minute
+		if erc.SLA > 0 {
+			sla = time.Duration(erc.SLA) * time.Second
+		}
+
+		p := provider.NewExitRootConsistencyProvider(eb, interval, sla)
+		providers = append(providers, p)
+	}
+
+	if bp := config.Config().Providers.BatchPipeline; bp != nil {
+		interval := config.Config().Runner.Interval
+		if bp.Interval > 0 {
+			interval = bp.Interval
+		}
+
+		p := provider.NewBatchPipelineProvider(eb, interval)
+		providers = append(providers, p)
+	}
+
+	if vs := config.Config().Providers.ValidatorSet; vs != nil {
+		interval := config.Config().Runner.Interval
+		if vs.Interval > 0 {
+			interval = vs.Interval
+		}
+
+		var networks []network.Network
+		seen := make(map[string]struct{})
+		for _, h := range config.Config().Providers.HeimdallEndpoints {
+			if _, ok := seen[h.Name]; ok {
+				continue
+			}
+			seen[h.Name] = struct{}{}
+
+			n, err := network.GetNetworkByName(h.Name)
+			if err != nil {
+				return err
+			}
+			networks = append(networks, n)
+		}
+
+		p := provider.NewValidatorSetProvider(networks, eb, interval)
+		providers = append(providers, p)
 	}
 
 	for _, h := range config.Config().Providers.HeimdallEndpoints {
@@ -106,11 +230,14 @@ func Init(ctx context.Context) error {
 			interval = h.Interval
 		}
 
-		if h.Version == 0 {
-			h.Version = 1
+		endpoints := provider.NewHeimdallEndpoints(h)
+		if len(endpoints) == 0 {
+			return fmt.Errorf("no endpoints configured for heimdall provider %s", h.Label)
 		}
 
-		p := provider.NewHeimdallProvider(n, h.TendermintURL, h.HeimdallURL, h.Label, eb, interval, h.Version)
+		staleAfter := time.Duration(h.StaleAfter) * time.Second
+
+		p := provider.NewHeimdallProvider(n, endpoints, h.Label, eb, interval, staleAfter)
 		providers = append(providers, p)
 	}
 
@@ -139,14 +266,8 @@ func Init(ctx context.Context) error {
 		providers = append(providers, p)
 	}
 
-	if er := config.Config().Providers.ExchangeRates; er != nil {
-		interval := config.Config().Runner.Interval
-		if er.Interval > 0 {
-			interval = er.Interval
-		}
-
-		p := provider.NewExchangeRatesProvider(er.CoinbaseURL, er.Tokens, eb, interval)
-		providers = append(providers, p)
+	for _, p := range providers {
+		mgr.add(p)
 	}
 
 	observers = observer.GetEnabledObserverSet()